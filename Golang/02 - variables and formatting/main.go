@@ -0,0 +1,50 @@
+/*
+This example shows the different ways to declare a variable in Go and how to
+print them. The printing itself lives in the fmtutil package (see the fmtutil
+folder), so it can be reused by the other examples too.
+*/
+package main
+
+import (
+	"fmt"
+
+	"Golang/fmtutil"
+)
+
+func main() {
+	// var with an explicit type.
+	var firstName string = "Ada"
+
+	// var without a type: Go works it out from the value.
+	var middleName = "King"
+
+	// := declares and assigns in one step, only inside functions.
+	lastName := "Lovelace"
+
+	// A variable declared without a value gets the zero value, "" for strings.
+	var nickname string
+	nickname = "Countess"
+
+	fmt.Println(fmtutil.FormatLabeled("First name", firstName))
+	fmt.Println(fmtutil.FormatLabeled("Middle name", middleName))
+	fmt.Println(fmtutil.FormatLabeled("Last name", lastName))
+	fmt.Println(fmtutil.FormatLabeled("Nickname", nickname))
+
+	// Other types work too, FormatLabeled prints them with %v.
+	age := 36
+	height := 1.65
+	fmt.Println(fmtutil.FormatLabeled("Age", age))
+	fmt.Println(fmtutil.FormatLabeled("Height", height))
+	fmt.Println()
+
+	// The same data as a table. The last row is shorter on purpose:
+	// the missing cell is left blank.
+	fmt.Print(fmtutil.FormatTable([][]string{
+		{"Variable", "Value"},
+		{"firstName", firstName},
+		{"middleName", middleName},
+		{"lastName", lastName},
+		{"nickname", nickname},
+		{"city"},
+	}))
+}
@@ -0,0 +1,135 @@
+/*
+This example reads a name, an age and a height from the keyboard.
+
+fmt.Scan(&x) is only a shortcut for fmt.Fscan(os.Stdin, &x). Reading straight
+from os.Stdin has a well known problem: the Scan functions stop as soon as the
+value is complete, so the newline the user typed (and anything else after the
+number) stays in the input buffer. The next Scanf then finds that leftover
+newline first, fails with "unexpected newline" and looks as if it was skipped.
+The same happens after a typo: "abc" is rejected, but "bc" is still waiting and
+makes the next attempt fail immediately.
+
+To avoid it every helper below reads through a bufio.Reader and grabs a
+whole line with ReadString('\n') before scanning the value out of it.
+Because ReadString always consumes the newline too, each prompt starts on a
+clean line. The reader is passed to the helpers instead of being a global,
+so main_test.go can feed them a string instead of the keyboard.
+
+So this example does not call fmt.Scan or fmt.Scanf themselves, which
+always read os.Stdin directly. It uses their io.Reader versions instead:
+fmt.Fscan for the name and fmt.Fscanf on each line read. The helpers take a
+*bufio.Reader rather than any io.Reader on purpose. Wrapping an io.Reader in
+a new bufio.Reader inside every call would throw away whatever the previous
+call had already buffered.
+*/
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// maxAttempts is how many times a helper asks again before giving up.
+const maxAttempts = 3
+
+// readLine returns the next line of input without the trailing newline.
+// It is the fallback for values that contain spaces, like "Ada Lovelace",
+// where fmt.Scan would stop at the first space.
+func readLine(reader *bufio.Reader) (string, error) {
+	line, err := reader.ReadString('\n')
+	line = strings.TrimRight(line, "\r\n")
+	if err == io.EOF && line != "" {
+		// The last line of a file might not end with a newline.
+		return line, nil
+	}
+	return line, err
+}
+
+// scanValue prints prompt, reads one full line and scans a single value out
+// of it with the given verb, asking again up to maxAttempts times when the
+// input is malformed. Since the whole line has already been read, nothing is
+// left behind for the next prompt, whatever the user typed.
+func scanValue(reader *bufio.Reader, prompt, verb string, v any) error {
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		fmt.Print(prompt)
+		line, err := readLine(reader)
+		if err != nil {
+			return fmt.Errorf("reading input: %w", err)
+		}
+
+		err = scanOne(line, verb, v)
+		if err == nil {
+			return nil
+		}
+		fmt.Printf("Invalid input (%v), attempt %d of %d.\n", err, attempt, maxAttempts)
+	}
+	return fmt.Errorf("no valid input after %d attempts", maxAttempts)
+}
+
+// scanOne scans exactly one value out of line. Anything but spaces after the
+// value is reported as an error, so "12abc" is not silently read as 12.
+func scanOne(line, verb string, v any) error {
+	if strings.TrimSpace(line) == "" {
+		return errors.New("nothing was typed")
+	}
+	r := strings.NewReader(line)
+	if _, err := fmt.Fscanf(r, verb, v); err != nil {
+		return err
+	}
+	if rest := line[len(line)-r.Len():]; strings.TrimSpace(rest) != "" {
+		return fmt.Errorf("unexpected %q after the value", rest)
+	}
+	return nil
+}
+
+// readInt asks for a whole number.
+func readInt(reader *bufio.Reader, prompt string) (int, error) {
+	var n int
+	err := scanValue(reader, prompt, "%d", &n)
+	return n, err
+}
+
+// readFloat asks for a decimal number.
+func readFloat(reader *bufio.Reader, prompt string) (float64, error) {
+	var f float64
+	err := scanValue(reader, prompt, "%g", &f)
+	return f, err
+}
+
+func main() {
+	// One reader for the whole program: a new bufio.Reader for every call
+	// would lose whatever the previous one had already buffered from
+	// os.Stdin.
+	reader := bufio.NewReader(os.Stdin)
+	var name string
+
+	// fmt.Fscan reads one word, just like fmt.Scan would.
+	fmt.Print("What is your name? ")
+	if _, err := fmt.Fscan(reader, &name); err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
+	// Without this call the newline after the name would still be waiting.
+	if _, err := readLine(reader); err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
+
+	age, err := readInt(reader, "How old are you? ")
+	if err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
+
+	height, err := readFloat(reader, "How tall are you (in meters)? ")
+	if err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Hello %s, you are %d years old and %.2f m tall.\n", name, age, height)
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+)
+
+func TestReadInt(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    int
+		wantErr bool
+	}{
+		{"valid", "42\n", 42, false},
+		{"spaces around", "  7  \n", 7, false},
+		{"no final newline", "13", 13, false},
+		{"negative", "-5\n", -5, false},
+		{"retry after typo", "abc\n12\n", 12, false},
+		{"retry after empty line", "\n\n99\n", 99, false},
+		{"trailing garbage", "12abc\n12\n", 12, false},
+		{"three bad lines", "a\nb\nc\n4\n", 0, true},
+		{"end of input", "", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readInt(bufio.NewReader(strings.NewReader(tt.input)), "")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error: %v", err, tt.wantErr)
+			}
+			if err == nil && got != tt.want {
+				t.Errorf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadFloat(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    float64
+		wantErr bool
+	}{
+		{"decimal", "1.75\n", 1.75, false},
+		{"whole number", "2\n", 2, false},
+		{"windows line end", "1.8\r\n", 1.8, false},
+		{"comma is rejected", "1,75\n1.75\n", 1.75, false},
+		{"three bad lines", "x\ny\nz\n", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readFloat(bufio.NewReader(strings.NewReader(tt.input)), "")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error: %v", err, tt.wantErr)
+			}
+			if err == nil && got != tt.want {
+				t.Errorf("got %g, want %g", got, tt.want)
+			}
+		})
+	}
+}
+
+// The value after a retry must come from the next line, and the line after
+// it must still be there for the next helper.
+func TestNoLeftoverInput(t *testing.T) {
+	r := bufio.NewReader(strings.NewReader("Ada Lovelace\n36abc\n36\n1.65\n"))
+	name, err := readLine(r)
+	if err != nil || name != "Ada Lovelace" {
+		t.Fatalf("readLine = %q, %v", name, err)
+	}
+	age, err := readInt(r, "")
+	if err != nil || age != 36 {
+		t.Fatalf("readInt = %d, %v", age, err)
+	}
+	height, err := readFloat(r, "")
+	if err != nil || height != 1.65 {
+		t.Fatalf("readFloat = %g, %v", height, err)
+	}
+}
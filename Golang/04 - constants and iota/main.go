@@ -0,0 +1,80 @@
+/*
+Constants are values fixed at compile time, declared with const instead of var.
+Inside a const block iota starts at 0 and grows by one on every line, which is
+the usual way to build an enumeration in Go.
+*/
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Weekday gives the day constants their own type: a Weekday and a plain int
+// cannot be mixed without a conversion, so the compiler catches mistakes.
+type Weekday int
+
+const (
+	Sunday    Weekday = iota // 0
+	Monday                   // 1, the type and "= iota" are repeated for us
+	Tuesday                  // 2
+	Wednesday                // 3
+	Thursday                 // 4
+	Friday                   // 5
+	Saturday                 // 6
+)
+
+// daysInWeek is an untyped constant, it can be used with any numeric type.
+const daysInWeek = 7
+
+// String makes Weekday a fmt.Stringer, so Println prints the name instead of
+// the number. Values outside Sunday..Saturday print as "Weekday(N)".
+func (d Weekday) String() string {
+	switch d {
+	case Sunday:
+		return "Sunday"
+	case Monday:
+		return "Monday"
+	case Tuesday:
+		return "Tuesday"
+	case Wednesday:
+		return "Wednesday"
+	case Thursday:
+		return "Thursday"
+	case Friday:
+		return "Friday"
+	case Saturday:
+		return "Saturday"
+	default:
+		// Plain int conversion, calling d.String() here would recurse forever.
+		return fmt.Sprintf("Weekday(%d)", int(d))
+	}
+}
+
+// ParseWeekday returns the Weekday named s, ignoring upper and lower case.
+func ParseWeekday(s string) (Weekday, error) {
+	for d := Sunday; d <= Saturday; d++ {
+		if strings.EqualFold(s, d.String()) {
+			return d, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown weekday %q", s)
+}
+
+func main() {
+	for d := Sunday; d < daysInWeek; d++ {
+		fmt.Printf("%d = %v\n", d, d)
+	}
+
+	// A value that is not a real day does not panic.
+	fmt.Println(Weekday(9))
+
+	for _, name := range []string{"friday", "MONDAY", "Someday"} {
+		d, err := ParseWeekday(name)
+		if err != nil {
+			fmt.Println("Error:", err)
+			continue
+		}
+		fmt.Printf("%q is %v\n", name, d)
+	}
+}
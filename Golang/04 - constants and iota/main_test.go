@@ -0,0 +1,62 @@
+package main
+
+import "testing"
+
+func TestWeekdayRoundTrip(t *testing.T) {
+	tests := []struct {
+		day  Weekday
+		name string
+	}{
+		{Sunday, "Sunday"},
+		{Monday, "Monday"},
+		{Tuesday, "Tuesday"},
+		{Wednesday, "Wednesday"},
+		{Thursday, "Thursday"},
+		{Friday, "Friday"},
+		{Saturday, "Saturday"},
+	}
+	if len(tests) != daysInWeek {
+		t.Fatalf("%d days tested, want %d", len(tests), daysInWeek)
+	}
+	for _, tt := range tests {
+		if got := tt.day.String(); got != tt.name {
+			t.Errorf("Weekday(%d).String() = %q, want %q", int(tt.day), got, tt.name)
+		}
+		d, err := ParseWeekday(tt.day.String())
+		if err != nil || d != tt.day {
+			t.Errorf("ParseWeekday(%q) = %v, %v, want %v", tt.name, d, err, tt.day)
+		}
+	}
+}
+
+func TestParseWeekdayIgnoresCase(t *testing.T) {
+	for _, s := range []string{"friday", "FRIDAY", "fRiDaY"} {
+		if d, err := ParseWeekday(s); err != nil || d != Friday {
+			t.Errorf("ParseWeekday(%q) = %v, %v, want Friday", s, d, err)
+		}
+	}
+}
+
+func TestParseWeekdayInvalid(t *testing.T) {
+	for _, s := range []string{"Someday", "", "Mon", " Monday"} {
+		if _, err := ParseWeekday(s); err == nil {
+			t.Errorf("ParseWeekday(%q) returned no error", s)
+		}
+	}
+}
+
+func TestWeekdayOutOfRange(t *testing.T) {
+	tests := []struct {
+		day  Weekday
+		want string
+	}{
+		{7, "Weekday(7)"},
+		{-1, "Weekday(-1)"},
+		{100, "Weekday(100)"},
+	}
+	for _, tt := range tests {
+		if got := tt.day.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+/*
+An array has a fixed length that is part of its type ([3]int) and is copied
+every time it is assigned or passed to a function.
+
+A slice ([]int) is a small view over an array: a pointer to the first element,
+a length and a capacity. Copying a slice copies the view, not the elements, so
+two slices can share the same backing array.
+
+append is where this gets surprising:
+  - if the slice still has spare capacity, append writes the new element into
+    the shared backing array, where other slices can see it;
+  - if it does not, append allocates a new, bigger array and copies the
+    elements, so the result no longer shares anything with the original.
+*/
+package main
+
+import "fmt"
+
+// safeAppend returns dst followed by src in a brand new backing array, so the
+// result never shares memory with dst, whatever its capacity.
+func safeAppend(dst, src []int) []int {
+	out := make([]int, len(dst), len(dst)+len(src))
+	copy(out, dst)
+	return append(out, src...)
+}
+
+// demonstrateAliasing appends to the same slice twice. Because base has room
+// for one more element, both appends write into the same spot of the same
+// backing array, and the second one overwrites the first.
+func demonstrateAliasing() (first, second []int) {
+	base := make([]int, 3, 4) // length 3, room for 4
+	base[0], base[1], base[2] = 1, 2, 3
+
+	first = append(base, 100)
+	second = append(base, 200)
+	// first is now [1 2 3 200], not [1 2 3 100]!
+	return first, second
+}
+
+func main() {
+	// Arrays are values: changing the copy leaves the original alone.
+	a := [3]int{1, 2, 3}
+	b := a
+	b[0] = 99
+	fmt.Println("array a:", a, "array b:", b)
+
+	// Slices share: changing t also changes s.
+	s := []int{1, 2, 3}
+	t := s
+	t[0] = 99
+	fmt.Println("slice s:", s, "slice t:", t)
+
+	first, second := demonstrateAliasing()
+	fmt.Println("first: ", first, "second:", second)
+
+	// Without spare capacity append has to allocate, so nothing is shared.
+	full := []int{1, 2, 3} // length 3, capacity 3
+	grown := append(full, 4)
+	grown[0] = 99
+	fmt.Println("full: ", full, "grown:", grown)
+
+	// safeAppend always copies, even when there is room.
+	base := make([]int, 3, 10)
+	copy(base, []int{1, 2, 3})
+	joined := safeAppend(base, []int{4, 5})
+	joined[0] = 99
+	fmt.Println("base:", base, "joined:", joined, "base backing array:", base[:5])
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSafeAppendLeavesBackingArrayAlone(t *testing.T) {
+	base := make([]int, 3, 10) // plenty of spare capacity
+	copy(base, []int{1, 2, 3})
+
+	joined := safeAppend(base, []int{4, 5})
+	if want := []int{1, 2, 3, 4, 5}; !reflect.DeepEqual(joined, want) {
+		t.Fatalf("safeAppend = %v, want %v", joined, want)
+	}
+
+	// A plain append would have written 4 and 5 into base's spare capacity.
+	if spare := base[:5]; !reflect.DeepEqual(spare, []int{1, 2, 3, 0, 0}) {
+		t.Errorf("base backing array changed: %v", spare)
+	}
+
+	joined[0] = 99
+	if base[0] != 1 {
+		t.Errorf("joined shares memory with base: base[0] = %d", base[0])
+	}
+}
+
+func TestSafeAppend(t *testing.T) {
+	tests := []struct {
+		name     string
+		dst, src []int
+		want     []int
+	}{
+		{"both empty", nil, nil, []int{}},
+		{"empty dst", nil, []int{1}, []int{1}},
+		{"empty src", []int{1, 2}, nil, []int{1, 2}},
+		{"both", []int{1}, []int{2, 3}, []int{1, 2, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := safeAppend(tt.dst, tt.src); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("safeAppend(%v, %v) = %v, want %v", tt.dst, tt.src, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDemonstrateAliasing(t *testing.T) {
+	first, second := demonstrateAliasing()
+	want := []int{1, 2, 3, 200}
+	if !reflect.DeepEqual(first, want) || !reflect.DeepEqual(second, want) {
+		t.Errorf("first = %v, second = %v, want both %v", first, second, want)
+	}
+}
@@ -0,0 +1,108 @@
+/*
+An interface is a list of methods. Any type that has those methods satisfies
+the interface automatically, there is no "implements" keyword in Go. This lets
+TotalArea work with circles, rectangles and triangles without knowing which
+one it is looking at.
+*/
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+)
+
+// Shape is anything that can tell its area and its perimeter.
+type Shape interface {
+	Area() float64
+	Perimeter() float64
+}
+
+type Circle struct {
+	Radius float64
+}
+
+func (c Circle) Area() float64      { return math.Pi * c.Radius * c.Radius }
+func (c Circle) Perimeter() float64 { return 2 * math.Pi * c.Radius }
+
+type Rectangle struct {
+	Width, Height float64
+}
+
+func (r Rectangle) Area() float64      { return r.Width * r.Height }
+func (r Rectangle) Perimeter() float64 { return 2 * (r.Width + r.Height) }
+
+// Triangle has unexported sides, so the only way to build one from another
+// package is NewTriangle, which checks that the sides make sense.
+type Triangle struct {
+	a, b, c float64
+}
+
+// ErrInvalidTriangle is returned by NewTriangle for sides that cannot close.
+var ErrInvalidTriangle = errors.New("sides cannot form a triangle")
+
+// NewTriangle returns the triangle with sides a, b and c. Every side must be
+// positive and shorter than the sum of the other two (the triangle
+// inequality), otherwise the three sides lie flat or do not meet at all.
+func NewTriangle(a, b, c float64) (Triangle, error) {
+	if a <= 0 || b <= 0 || c <= 0 || a+b <= c || a+c <= b || b+c <= a {
+		return Triangle{}, fmt.Errorf("%w: %g, %g, %g", ErrInvalidTriangle, a, b, c)
+	}
+	return Triangle{a: a, b: b, c: c}, nil
+}
+
+// Area uses Heron's formula, which only needs the three sides.
+func (t Triangle) Area() float64 {
+	s := t.Perimeter() / 2
+	return math.Sqrt(s * (s - t.a) * (s - t.b) * (s - t.c))
+}
+
+func (t Triangle) Perimeter() float64 { return t.a + t.b + t.c }
+
+// TotalArea adds up the area of every shape.
+func TotalArea(shapes []Shape) float64 {
+	total := 0.0
+	for _, s := range shapes {
+		total += s.Area()
+	}
+	return total
+}
+
+// describe uses a type switch to find out which concrete type is stored
+// inside the interface value.
+func describe(s Shape) string {
+	switch v := s.(type) {
+	case Circle:
+		return fmt.Sprintf("Circle with radius %g", v.Radius)
+	case Rectangle:
+		return fmt.Sprintf("Rectangle %g x %g", v.Width, v.Height)
+	case Triangle:
+		return fmt.Sprintf("Triangle with sides %g, %g, %g", v.a, v.b, v.c)
+	default:
+		return fmt.Sprintf("unknown shape %T", v)
+	}
+}
+
+func main() {
+	triangle, err := NewTriangle(3, 4, 5)
+	if err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
+
+	shapes := []Shape{
+		Circle{Radius: 1},
+		Rectangle{Width: 2, Height: 3},
+		triangle,
+	}
+	for _, s := range shapes {
+		fmt.Printf("%s: area %.2f, perimeter %.2f\n", describe(s), s.Area(), s.Perimeter())
+	}
+	fmt.Printf("Total area: %.2f\n", TotalArea(shapes))
+
+	// 1 + 2 is not longer than 10, the sides never meet.
+	if _, err := NewTriangle(1, 2, 10); err != nil {
+		fmt.Println("Error:", err)
+	}
+}
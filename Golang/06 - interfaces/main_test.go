@@ -0,0 +1,76 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func mustTriangle(t *testing.T, a, b, c float64) Triangle {
+	t.Helper()
+	tr, err := NewTriangle(a, b, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tr
+}
+
+func TestShapes(t *testing.T) {
+	const eps = 1e-9
+	tests := []struct {
+		name          string
+		shape         Shape
+		area, perim   float64
+		wantDescribed string
+	}{
+		{"unit circle", Circle{Radius: 1}, math.Pi, 2 * math.Pi, "Circle with radius 1"},
+		{"circle r=2", Circle{Radius: 2}, 4 * math.Pi, 4 * math.Pi, "Circle with radius 2"},
+		{"rectangle", Rectangle{Width: 2, Height: 3}, 6, 10, "Rectangle 2 x 3"},
+		{"square", Rectangle{Width: 4, Height: 4}, 16, 16, "Rectangle 4 x 4"},
+		{"3-4-5 triangle", mustTriangle(t, 3, 4, 5), 6, 12, "Triangle with sides 3, 4, 5"},
+		{"equilateral", mustTriangle(t, 2, 2, 2), math.Sqrt(3), 6, "Triangle with sides 2, 2, 2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.shape.Area(); math.Abs(got-tt.area) > eps {
+				t.Errorf("Area = %g, want %g", got, tt.area)
+			}
+			if got := tt.shape.Perimeter(); math.Abs(got-tt.perim) > eps {
+				t.Errorf("Perimeter = %g, want %g", got, tt.perim)
+			}
+			if got := describe(tt.shape); got != tt.wantDescribed {
+				t.Errorf("describe = %q, want %q", got, tt.wantDescribed)
+			}
+		})
+	}
+}
+
+func TestTotalArea(t *testing.T) {
+	shapes := []Shape{Rectangle{Width: 2, Height: 3}, mustTriangle(t, 3, 4, 5)}
+	if got := TotalArea(shapes); got != 12 {
+		t.Errorf("TotalArea = %g, want 12", got)
+	}
+	if got := TotalArea(nil); got != 0 {
+		t.Errorf("TotalArea(nil) = %g, want 0", got)
+	}
+}
+
+func TestNewTriangleInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b, c float64
+	}{
+		{"sides do not meet", 1, 2, 10},
+		{"flat", 1, 2, 3},
+		{"zero side", 0, 4, 5},
+		{"negative side", -3, 4, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewTriangle(tt.a, tt.b, tt.c)
+			if !errors.Is(err, ErrInvalidTriangle) {
+				t.Errorf("NewTriangle(%g, %g, %g) = %v, want ErrInvalidTriangle", tt.a, tt.b, tt.c, err)
+			}
+		})
+	}
+}
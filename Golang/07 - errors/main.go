@@ -0,0 +1,68 @@
+/*
+In Go an error is just a value that has an Error() string method. Functions
+return it as their last result and the caller checks it with if err != nil.
+
+fmt.Errorf with the %w verb wraps an error inside a new one that adds some
+context. errors.Is and errors.As look through all the wrapping layers:
+  - errors.Is(err, target) asks "is target somewhere in this chain?"
+  - errors.As(err, &target) finds the first error of target's type and
+    copies it into target, so we can read its fields.
+*/
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNotFound is a sentinel error: one fixed value callers can compare with.
+var ErrNotFound = errors.New("not found")
+
+// ValidationError is a custom error type that carries extra information.
+type ValidationError struct {
+	Field string
+	Msg   string
+}
+
+// Error makes ValidationError satisfy the error interface. It has a pointer
+// receiver, so a *ValidationError is what gets returned and matched.
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid %s: %s", e.Field, e.Msg)
+}
+
+var users = map[int]string{
+	1: "Ada",
+	2: "Grace",
+}
+
+// lookup returns the name of the user with the given id.
+func lookup(id int) (string, error) {
+	if id <= 0 {
+		return "", &ValidationError{Field: "id", Msg: "must be positive"}
+	}
+	name, ok := users[id]
+	if !ok {
+		// %w keeps ErrNotFound inside the new error, %v would only keep the text.
+		return "", fmt.Errorf("lookup user %d: %w", id, ErrNotFound)
+	}
+	return name, nil
+}
+
+func main() {
+	for _, id := range []int{1, 7, -3} {
+		name, err := lookup(id)
+
+		var validationErr *ValidationError
+		switch {
+		case err == nil:
+			fmt.Printf("User %d is %s\n", id, name)
+		case errors.Is(err, ErrNotFound):
+			// err == ErrNotFound would be false here because of the wrapping.
+			fmt.Println("Missing:", err)
+		case errors.As(err, &validationErr):
+			fmt.Printf("Bad field %q: %s\n", validationErr.Field, validationErr.Msg)
+		default:
+			fmt.Println("Unexpected error:", err)
+		}
+	}
+}
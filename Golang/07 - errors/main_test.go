@@ -0,0 +1,53 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		id   int
+		want string
+	}{
+		{1, "Ada"},
+		{2, "Grace"},
+	}
+	for _, tt := range tests {
+		if got, err := lookup(tt.id); err != nil || got != tt.want {
+			t.Errorf("lookup(%d) = %q, %v, want %q", tt.id, got, err, tt.want)
+		}
+	}
+}
+
+func TestLookupNotFound(t *testing.T) {
+	_, err := lookup(7)
+	if err == ErrNotFound {
+		t.Fatal("the error is not wrapped")
+	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("err = %v, want it to wrap ErrNotFound", err)
+	}
+	// Wrapping it once more keeps ErrNotFound visible.
+	if outer := fmt.Errorf("handle request: %w", err); !errors.Is(outer, ErrNotFound) {
+		t.Errorf("errors.Is lost ErrNotFound through a second wrap: %v", outer)
+	}
+}
+
+func TestLookupValidation(t *testing.T) {
+	for _, id := range []int{0, -3} {
+		_, err := lookup(id)
+		err = fmt.Errorf("handle request: %w", err)
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Fatalf("lookup(%d): err = %v, want a *ValidationError", id, err)
+		}
+		if validationErr.Field != "id" || validationErr.Msg != "must be positive" {
+			t.Errorf("lookup(%d): fields %+v", id, *validationErr)
+		}
+		if errors.Is(err, ErrNotFound) {
+			t.Errorf("lookup(%d): a validation error must not match ErrNotFound", id)
+		}
+	}
+}
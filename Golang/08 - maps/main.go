@@ -0,0 +1,60 @@
+/*
+A map stores values by key. Ranging over a map visits every key, but Go
+randomizes the order on purpose so nobody depends on it: run this example
+twice and the first loop may print the colors in a different order.
+When the order matters, collect the keys, sort them and use that order.
+*/
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// PrintSorted returns each "key: value" pair of m on its own line, with the
+// keys in alphabetical order, so the same map always prints the same text.
+func PrintSorted(m map[string]int) string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	for _, k := range keys {
+		fmt.Fprintf(&sb, "%s: %d\n", k, m[k])
+	}
+	return sb.String()
+}
+
+// CountWords counts how many times each word appears in text. A word is a run
+// of letters and digits, so any other character ends it: "one,two" is two
+// words, "Go," and "go" count as the same word, and "don't" becomes "don" and
+// "t". Words are lowercased before counting.
+func CountWords(text string) map[string]int {
+	counts := make(map[string]int)
+	notWord := func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }
+	for _, word := range strings.FieldsFunc(text, notWord) {
+		counts[strings.ToLower(word)]++
+	}
+	return counts
+}
+
+func main() {
+	colors := map[string]int{"red": 1, "green": 2, "blue": 3, "yellow": 4}
+
+	fmt.Println("Random order:")
+	for k, v := range colors {
+		fmt.Printf("%s: %d\n", k, v)
+	}
+
+	fmt.Println("\nSorted order:")
+	fmt.Print(PrintSorted(colors))
+
+	text := `Go is fun. Go is fast -- and, honestly, "fun" is the point!
+Don't you think go is FUN?`
+	fmt.Println("\nWord count:")
+	fmt.Print(PrintSorted(CountWords(text)))
+}
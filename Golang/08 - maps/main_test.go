@@ -0,0 +1,59 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCountWords(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want map[string]int
+	}{
+		{"empty", "", map[string]int{}},
+		{"only punctuation", "-- ... !", map[string]int{}},
+		{"comma without space", "one,two", map[string]int{"one": 1, "two": 1}},
+		{"case folded", "Go go GO", map[string]int{"go": 3}},
+		{"digits", "route 66, route66", map[string]int{"route": 1, "66": 1, "route66": 1}},
+		{"unicode letters", "café Café", map[string]int{"café": 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CountWords(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CountWords(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCountWordsParagraph(t *testing.T) {
+	text := `Go is fun. Go is fast -- and, honestly, "fun" is the point!
+Don't you think go is FUN?`
+	want := `and: 1
+don: 1
+fast: 1
+fun: 3
+go: 3
+honestly: 1
+is: 4
+point: 1
+t: 1
+the: 1
+think: 1
+you: 1
+`
+	if got := PrintSorted(CountWords(text)); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestPrintSorted(t *testing.T) {
+	if got := PrintSorted(nil); got != "" {
+		t.Errorf("PrintSorted(nil) = %q, want empty", got)
+	}
+	got := PrintSorted(map[string]int{"b": 2, "a": 1, "c": 3})
+	if want := "a: 1\nb: 2\nc: 3\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,86 @@
+/*
+Go has no classes and no inheritance. Instead a struct can embed another one
+by writing its type without a field name. The fields and methods of the
+embedded struct are "promoted": they can be used as if they belonged to the
+outer struct. The outer struct can still define a method with the same name,
+which then hides (overrides) the promoted one.
+*/
+package main
+
+import "fmt"
+
+type Animal struct {
+	Name string
+	Legs int
+}
+
+// Describe is defined only on Animal, but Dog and Bird get it for free.
+func (a Animal) Describe() string {
+	return fmt.Sprintf("%s has %d legs", a.Name, a.Legs)
+}
+
+func (a Animal) Speak() string { return "..." }
+
+// String makes Animal, and everything embedding it, a fmt.Stringer.
+func (a Animal) String() string {
+	return a.Describe() + " and says " + a.Speak()
+}
+
+// Dog embeds Animal and overrides Speak.
+type Dog struct {
+	Animal
+	Breed string
+}
+
+func (d Dog) Speak() string { return "Woof!" }
+
+// String has to be redefined too: the promoted Animal.String calls
+// Animal.Speak, because inside Animal's methods the receiver is only an
+// Animal and knows nothing about Dog.
+func (d Dog) String() string {
+	return d.Describe() + " and says " + d.Speak()
+}
+
+// Bird embeds Animal but also declares its own Name field. The field of the
+// outer struct wins, so b.Name is the bird's nickname while b.Animal.Name is
+// still the species. Methods promoted from Animal, like Describe, only see
+// Animal's copy, which is easy to forget when reading b.Describe().
+//
+// If two structs embedded at the same level both had a Name field, b.Name
+// would not be shadowed but ambiguous, and using it would not compile.
+type Bird struct {
+	Animal
+	Name string
+}
+
+func (b Bird) Speak() string { return "Tweet!" }
+
+func (b Bird) String() string {
+	return fmt.Sprintf("%s (%s) and says %s", b.Describe(), b.Name, b.Speak())
+}
+
+// fleetDescribe returns the String() of every animal.
+func fleetDescribe(animals []fmt.Stringer) []string {
+	out := make([]string, 0, len(animals))
+	for _, a := range animals {
+		out = append(out, a.String())
+	}
+	return out
+}
+
+func main() {
+	dog := Dog{Animal: Animal{Name: "Rex", Legs: 4}, Breed: "Beagle"}
+	bird := Bird{Animal: Animal{Name: "Parrot", Legs: 2}, Name: "Polly"}
+
+	// Name and Describe come from the embedded Animal.
+	fmt.Println(dog.Name, "-", dog.Describe())
+	// Speak is Dog's own version, the Animal one is still reachable.
+	fmt.Println(dog.Speak(), dog.Animal.Speak())
+
+	// bird.Name and bird.Animal.Name are two different fields.
+	fmt.Println(bird.Name, "/", bird.Animal.Name, "-", bird.Describe())
+
+	for _, line := range fleetDescribe([]fmt.Stringer{dog, bird, Animal{Name: "Snake"}}) {
+		fmt.Println(line)
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestPromotedMethods(t *testing.T) {
+	dog := Dog{Animal: Animal{Name: "Rex", Legs: 4}, Breed: "Beagle"}
+
+	// Describe and Name are promoted from Animal.
+	if got, want := dog.Describe(), "Rex has 4 legs"; got != want {
+		t.Errorf("dog.Describe() = %q, want %q", got, want)
+	}
+	if dog.Name != dog.Animal.Name {
+		t.Errorf("dog.Name = %q, dog.Animal.Name = %q", dog.Name, dog.Animal.Name)
+	}
+}
+
+func TestOverriddenMethods(t *testing.T) {
+	dog := Dog{Animal: Animal{Name: "Rex", Legs: 4}}
+	bird := Bird{Animal: Animal{Name: "Parrot", Legs: 2}, Name: "Polly"}
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"dog speaks", dog.Speak(), "Woof!"},
+		{"embedded animal still silent", dog.Animal.Speak(), "..."},
+		{"bird speaks", bird.Speak(), "Tweet!"},
+		{"dog string uses Dog.Speak", dog.String(), "Rex has 4 legs and says Woof!"},
+		{"promoted string uses Animal.Speak", dog.Animal.String(), "Rex has 4 legs and says ..."},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestShadowedField(t *testing.T) {
+	bird := Bird{Animal: Animal{Name: "Parrot", Legs: 2}, Name: "Polly"}
+	if bird.Name != "Polly" || bird.Animal.Name != "Parrot" {
+		t.Errorf("bird.Name = %q, bird.Animal.Name = %q", bird.Name, bird.Animal.Name)
+	}
+	// The promoted Describe only sees Animal's Name.
+	if got, want := bird.Describe(), "Parrot has 2 legs"; got != want {
+		t.Errorf("bird.Describe() = %q, want %q", got, want)
+	}
+}
+
+func TestFleetDescribe(t *testing.T) {
+	got := fleetDescribe([]fmt.Stringer{
+		Dog{Animal: Animal{Name: "Rex", Legs: 4}},
+		Bird{Animal: Animal{Name: "Parrot", Legs: 2}, Name: "Polly"},
+		Animal{Name: "Snake"},
+	})
+	want := []string{
+		"Rex has 4 legs and says Woof!",
+		"Parrot has 2 legs (Polly) and says Tweet!",
+		"Snake has 0 legs and says ...",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
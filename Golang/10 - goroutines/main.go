@@ -0,0 +1,81 @@
+/*
+A goroutine is a function running at the same time as the rest of the program,
+started with the go keyword. Here a few worker goroutines take numbers from a
+jobs channel, square them and send the answers back on a results channel.
+A sync.WaitGroup tells us when every worker has finished.
+*/
+package main
+
+import (
+	"fmt"
+	"sync"
+)
+
+// job is one number to square, together with its position in the input.
+type job struct {
+	index int
+	value int
+}
+
+// result is the square of the job with the same index.
+type result struct {
+	index  int
+	square int
+}
+
+// worker squares every job it receives until the jobs channel is closed.
+func worker(jobs <-chan job, results chan<- result, wg *sync.WaitGroup) {
+	defer wg.Done()
+	for j := range jobs {
+		results <- result{index: j.index, square: j.value * j.value}
+	}
+}
+
+// runWorkers squares nums using the given number of workers. The workers
+// finish in any order, so every result carries the index of its input and is
+// stored back in that position: the output is the same for 1 or 100 workers.
+func runWorkers(nums []int, workers int) []int {
+	if workers < 1 {
+		workers = 1
+	}
+
+	jobs := make(chan job)
+	results := make(chan result)
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go worker(jobs, results, &wg)
+	}
+
+	// Send the jobs from their own goroutine, so we can start reading the
+	// results straight away.
+	go func() {
+		for i, n := range nums {
+			// i and n are passed by value inside the job. Before Go 1.22 a
+			// goroutine using i or n directly from a closure saw the single
+			// loop variable shared by every iteration, usually the last value.
+			jobs <- job{index: i, value: n}
+		}
+		close(jobs)
+	}()
+
+	// Close results once every worker is done, this ends the range below.
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	squares := make([]int, len(nums))
+	for r := range results {
+		squares[r.index] = r.square
+	}
+	return squares
+}
+
+func main() {
+	nums := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	fmt.Println("1 worker: ", runWorkers(nums, 1))
+	fmt.Println("8 workers:", runWorkers(nums, 8))
+}
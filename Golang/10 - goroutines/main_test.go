@@ -0,0 +1,47 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRunWorkers(t *testing.T) {
+	tests := []struct {
+		name string
+		nums []int
+		want []int
+	}{
+		{"empty", nil, []int{}},
+		{"one", []int{7}, []int{49}},
+		{"ten", []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, []int{1, 4, 9, 16, 25, 36, 49, 64, 81, 100}},
+		{"negative and zero", []int{-3, 0, 3}, []int{9, 0, 9}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			one := runWorkers(tt.nums, 1)
+			eight := runWorkers(tt.nums, 8)
+			if !slices.Equal(one, tt.want) {
+				t.Errorf("1 worker: %v, want %v", one, tt.want)
+			}
+			if !slices.Equal(eight, one) {
+				t.Errorf("8 workers: %v, want the same as 1 worker: %v", eight, one)
+			}
+		})
+	}
+}
+
+// Many more inputs than workers, so the results really arrive out of order.
+func TestRunWorkersOrder(t *testing.T) {
+	nums := make([]int, 1000)
+	for i := range nums {
+		nums[i] = i
+	}
+	for _, workers := range []int{0, 1, 8} {
+		got := runWorkers(nums, workers)
+		for i, sq := range got {
+			if sq != i*i {
+				t.Fatalf("%d workers: position %d holds %d, want %d", workers, i, sq, i*i)
+			}
+		}
+	}
+}
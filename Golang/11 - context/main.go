@@ -0,0 +1,71 @@
+/*
+A context.Context carries a deadline or a cancel signal to the code doing the
+work. The work keeps an eye on ctx.Done() and gives up as soon as it is closed,
+returning ctx.Err() to say why: context.DeadlineExceeded when the time ran out,
+context.Canceled when somebody called cancel().
+*/
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
+
+// fetchWithTimeout pretends to fetch something that takes d to arrive.
+// It returns early with ctx.Err() if the context ends first.
+func fetchWithTimeout(ctx context.Context, d time.Duration) (string, error) {
+	select {
+	case <-time.After(d):
+		return fmt.Sprintf("fetched after %v", d), nil
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// fetchWithRandomCancel runs the same fetch, but another goroutine calls
+// cancel() after a random delay between 0 and maxDelay, which must be > 0.
+func fetchWithRandomCancel(d, maxDelay time.Duration) (string, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	// Always call cancel, even if the fetch wins: it frees the context.
+	defer cancel()
+
+	go func() {
+		select {
+		case <-time.After(rand.N(maxDelay)):
+			cancel()
+		case <-ctx.Done():
+			// The fetch already returned, nothing left to cancel.
+		}
+	}()
+
+	return fetchWithTimeout(ctx, d)
+}
+
+func main() {
+	// The work takes 50ms but we only wait 10ms.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	_, err := fetchWithTimeout(ctx, 50*time.Millisecond)
+	cancel()
+	if errors.Is(err, context.DeadlineExceeded) {
+		fmt.Println("Too slow:", err)
+	}
+
+	// Now we are happy to wait a whole second.
+	ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+	msg, err := fetchWithTimeout(ctx, 50*time.Millisecond)
+	cancel()
+	fmt.Println(msg, err)
+
+	// Whether the fetch or the cancel comes first changes from run to run.
+	for i := 0; i < 3; i++ {
+		msg, err := fetchWithRandomCancel(50*time.Millisecond, 100*time.Millisecond)
+		if errors.Is(err, context.Canceled) {
+			fmt.Println("Cancelled:", err)
+			continue
+		}
+		fmt.Println(msg)
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// The margins are large on purpose: the work and the timeout are a hundred
+// times apart, so a slow machine cannot make the tests flaky.
+func TestFetchWithTimeout(t *testing.T) {
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		work    time.Duration
+		wantErr error
+	}{
+		{"timeout fires first", 10 * time.Millisecond, 10 * time.Second, context.DeadlineExceeded},
+		{"work finishes first", 10 * time.Second, time.Millisecond, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), tt.timeout)
+			defer cancel()
+			start := time.Now()
+			msg, err := fetchWithTimeout(ctx, tt.work)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && msg == "" {
+				t.Error("success returned an empty message")
+			}
+			if elapsed := time.Since(start); elapsed > 5*time.Second {
+				t.Errorf("took %v, it should return when the first of the two ends", elapsed)
+			}
+		})
+	}
+}
+
+func TestFetchWithTimeoutCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := fetchWithTimeout(ctx, 10*time.Second); !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+}
+
+// Either outcome is fine, but nothing else is.
+func TestFetchWithRandomCancel(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		msg, err := fetchWithRandomCancel(5*time.Millisecond, 10*time.Millisecond)
+		if err != nil && !errors.Is(err, context.Canceled) || err == nil && msg == "" {
+			t.Errorf("got %q, %v, want a message or context.Canceled", msg, err)
+		}
+	}
+}
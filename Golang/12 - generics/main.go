@@ -0,0 +1,30 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+func main() {
+	nums := []int{1, 2, 3, 4, 5, 6}
+
+	// T is int and U is string, Go infers both from the arguments.
+	words := Map(nums, strconv.Itoa)
+	fmt.Printf("%q\n", words)
+
+	evens := Filter(nums, func(n int) bool { return n%2 == 0 })
+	fmt.Println(evens)
+
+	sum := Reduce(nums, 0, func(acc, n int) int { return acc + n })
+	fmt.Println(sum)
+
+	// The same Reduce also works with different types for the total.
+	joined := Reduce(words, "", func(acc, w string) string { return acc + w })
+	fmt.Println(joined)
+
+	// A nil slice in, an empty slice out: JSON shows [] and not null.
+	var none []int
+	data, _ := json.Marshal(Map(none, strconv.Itoa))
+	fmt.Println(string(data))
+}
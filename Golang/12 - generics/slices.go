@@ -0,0 +1,39 @@
+/*
+Type parameters, written in square brackets, let one function work with many
+types while the compiler still checks every call. They need Go 1.18 or newer.
+"any" means the function accepts every type.
+*/
+package main
+
+// Map returns a new slice holding f applied to every element of s.
+// A nil s gives an empty, non-nil slice, which encoding/json writes as []
+// instead of null.
+func Map[T, U any](s []T, f func(T) U) []U {
+	out := make([]U, 0, len(s))
+	for _, v := range s {
+		out = append(out, f(v))
+	}
+	return out
+}
+
+// Filter returns a new slice with the elements of s for which pred is true.
+// Like Map, it never returns nil.
+func Filter[T any](s []T, pred func(T) bool) []T {
+	out := make([]T, 0)
+	for _, v := range s {
+		if pred(v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// Reduce combines the elements of s from left to right, starting from init.
+// An empty s returns init unchanged.
+func Reduce[T, U any](s []T, init U, f func(U, T) U) U {
+	acc := init
+	for _, v := range s {
+		acc = f(acc, v)
+	}
+	return acc
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestMap(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []int
+		want []string
+	}{
+		{"nil", nil, []string{}},
+		{"empty", []int{}, []string{}},
+		{"ints", []int{1, 2, 3}, []string{"1", "2", "3"}},
+		{"negative", []int{-4, 0}, []string{"-4", "0"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Map(tt.in, strconv.Itoa)
+			if got == nil {
+				t.Fatal("Map returned nil")
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Map(%v) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilter(t *testing.T) {
+	even := func(n int) bool { return n%2 == 0 }
+	tests := []struct {
+		name string
+		in   []int
+		want []int
+	}{
+		{"nil", nil, []int{}},
+		{"no evens", []int{1, 3, 5}, []int{}},
+		{"mixed", []int{1, 2, 3, 4, 5, 6}, []int{2, 4, 6}},
+		{"all evens", []int{0, -2, 8}, []int{0, -2, 8}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Filter(tt.in, even)
+			if got == nil {
+				t.Fatal("Filter returned nil")
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Filter(%v) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReduce(t *testing.T) {
+	sum := func(acc, n int) int { return acc + n }
+	tests := []struct {
+		name string
+		in   []int
+		init int
+		want int
+	}{
+		{"nil keeps init", nil, 10, 10},
+		{"one", []int{5}, 0, 5},
+		{"sum", []int{1, 2, 3, 4, 5, 6}, 0, 21},
+		{"with init", []int{1, 2, 3}, 100, 106},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Reduce(tt.in, tt.init, sum); got != tt.want {
+				t.Errorf("Reduce(%v, %d) = %d, want %d", tt.in, tt.init, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReduceChangesType(t *testing.T) {
+	got := Reduce([]int{1, 2, 3}, "", func(acc string, n int) string {
+		return acc + strconv.Itoa(n)
+	})
+	if got != "123" {
+		t.Errorf("got %q, want %q", got, "123")
+	}
+}
@@ -0,0 +1,108 @@
+/*
+encoding/json turns structs into JSON and back. Struct tags, the text between
+backquotes after a field, choose the JSON name of each field:
+  - `json:"port"` uses "port" as the key;
+  - `json:"port,omitempty"` also leaves the key out when the value is the
+    zero value (0, "", false, nil or an empty slice);
+  - `json:"-"` never writes the field at all.
+
+Only exported (capitalized) fields are encoded.
+*/
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+type Server struct {
+	Host string `json:"host"`
+	Port int    `json:"port"`
+}
+
+type Config struct {
+	Name    string   `json:"name"`
+	Debug   bool     `json:"debug,omitempty"`
+	Retries int      `json:"retries,omitempty"`
+	Servers []Server `json:"servers,omitempty"`
+	Secret  string   `json:"-"`
+}
+
+// Save returns c as indented JSON.
+func Save(c Config) ([]byte, error) {
+	return json.MarshalIndent(c, "", "  ")
+}
+
+// Load reads a Config from data. Keys that Config has no field for are
+// silently ignored, which is what json.Unmarshal always does.
+func Load(data []byte) (Config, error) {
+	var c Config
+	if err := json.Unmarshal(data, &c); err != nil {
+		return Config{}, describeError(err)
+	}
+	return c, nil
+}
+
+// LoadStrict is like Load but rejects unknown keys, which catches typos such as
+// "retires" instead of "retries".
+func LoadStrict(data []byte) (Config, error) {
+	var c Config
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&c); err != nil {
+		return Config{}, describeError(err)
+	}
+	return c, nil
+}
+
+// describeError wraps err with the offset in the input where decoding failed,
+// when encoding/json tells us.
+func describeError(err error) error {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		return fmt.Errorf("load config: malformed JSON at offset %d: %w", syntaxErr.Offset, err)
+	case errors.As(err, &typeErr):
+		return fmt.Errorf("load config: wrong type for %q at offset %d: %w", typeErr.Field, typeErr.Offset, err)
+	default:
+		return fmt.Errorf("load config: %w", err)
+	}
+}
+
+func main() {
+	full := Config{
+		Name:    "web",
+		Debug:   true,
+		Retries: 3,
+		Servers: []Server{{Host: "localhost", Port: 8080}, {Host: "example.com", Port: 443}},
+		Secret:  "hunter2",
+	}
+	data, err := Save(full)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	// Secret is missing thanks to `json:"-"`.
+	fmt.Println(string(data))
+
+	// Debug, Retries and Servers are zero values, omitempty drops them.
+	data, _ = Save(Config{Name: "minimal"})
+	fmt.Println(string(data))
+
+	// The round trip gives back the same values, except Secret.
+	data, _ = Save(full)
+	loaded, err := Load(data)
+	fmt.Printf("%+v %v\n", loaded, err)
+
+	typo := []byte(`{"name": "web", "retires": 5}`)
+	loaded, err = Load(typo)
+	fmt.Printf("Load ignores the typo: %+v %v\n", loaded, err)
+	_, err = LoadStrict(typo)
+	fmt.Println("LoadStrict:", err)
+
+	_, err = Load([]byte(`{"name": "web",}`))
+	fmt.Println("Malformed:", err)
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		in   Config
+	}{
+		{"minimal", Config{Name: "minimal"}},
+		{"full", Config{
+			Name:    "web",
+			Debug:   true,
+			Retries: 3,
+			Servers: []Server{{Host: "localhost", Port: 8080}, {Host: "example.com", Port: 443}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := Save(tt.in)
+			if err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+			got, err := Load(data)
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.in) {
+				t.Errorf("round trip = %+v, want %+v", got, tt.in)
+			}
+		})
+	}
+}
+
+func TestSaveOmitsFields(t *testing.T) {
+	data, err := Save(Config{Name: "web", Secret: "hunter2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "{\n  \"name\": \"web\"\n}"; string(data) != want {
+		t.Errorf("Save = %s, want %s", data, want)
+	}
+}
+
+func TestLoadMalformed(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		target  any
+		message string
+	}{
+		{"trailing comma", `{"name": "web",}`, new(*json.SyntaxError), "malformed JSON at offset"},
+		{"truncated", `{"name": "web"`, nil, "load config"},
+		{"wrong type", `{"name": "web", "retries": "three"}`, new(*json.UnmarshalTypeError), `wrong type for "retries"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load([]byte(tt.input))
+			if err == nil {
+				t.Fatal("Load succeeded, want an error")
+			}
+			if tt.target != nil && !errors.As(err, tt.target) {
+				t.Errorf("err = %v, want it to wrap %T", err, tt.target)
+			}
+			if !strings.Contains(err.Error(), tt.message) {
+				t.Errorf("err = %q, want it to contain %q", err, tt.message)
+			}
+		})
+	}
+}
+
+func TestLoadStrictRejectsUnknownField(t *testing.T) {
+	typo := []byte(`{"name": "web", "retires": 5}`)
+	if _, err := Load(typo); err != nil {
+		t.Errorf("Load: %v, want the unknown key ignored", err)
+	}
+	_, err := LoadStrict(typo)
+	if err == nil || !strings.Contains(err.Error(), `"retires"`) {
+		t.Errorf("LoadStrict err = %v, want it to name the unknown key", err)
+	}
+}
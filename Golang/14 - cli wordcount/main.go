@@ -0,0 +1,95 @@
+/*
+A small version of the Unix wc tool, showing the flag package.
+
+Usage:
+
+	go run main.go [-lines] [-words] [-chars] [file]
+
+Without a file it reads from the standard input, and without any flag it
+prints all three counts, like wc does.
+*/
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"unicode"
+)
+
+// countStats reads r to the end and counts its lines, words and characters.
+// Like wc, lines are the newline characters, so a last line without one is not
+// counted. Words are runs of non-space characters and chars are runes, not
+// bytes: "é" is one character even though UTF-8 stores it in two bytes.
+func countStats(r io.Reader) (lines, words, chars int, err error) {
+	br := bufio.NewReader(r)
+	inWord := false
+	for {
+		c, _, err := br.ReadRune()
+		if err == io.EOF {
+			return lines, words, chars, nil
+		}
+		if err != nil {
+			return lines, words, chars, err
+		}
+
+		chars++
+		if c == '\n' {
+			lines++
+		}
+		if unicode.IsSpace(c) {
+			inWord = false
+		} else if !inWord {
+			inWord = true
+			words++
+		}
+	}
+}
+
+func main() {
+	showLines := flag.Bool("lines", false, "print the number of lines")
+	showWords := flag.Bool("words", false, "print the number of words")
+	showChars := flag.Bool("chars", false, "print the number of characters")
+	flag.Parse()
+
+	if !*showLines && !*showWords && !*showChars {
+		*showLines, *showWords, *showChars = true, true, true
+	}
+
+	var input io.Reader = os.Stdin
+	name := ""
+	if flag.NArg() > 0 {
+		name = flag.Arg(0)
+		f, err := os.Open(name)
+		if err != nil {
+			// err already says which file and why, e.g.
+			// "open notes.txt: no such file or directory".
+			fmt.Fprintln(os.Stderr, "wordcount:", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		input = f
+	}
+
+	lines, words, chars, err := countStats(input)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "wordcount:", err)
+		os.Exit(1)
+	}
+
+	if *showLines {
+		fmt.Printf("%8d", lines)
+	}
+	if *showWords {
+		fmt.Printf("%8d", words)
+	}
+	if *showChars {
+		fmt.Printf("%8d", chars)
+	}
+	if name != "" {
+		fmt.Printf(" %s", name)
+	}
+	fmt.Println()
+}
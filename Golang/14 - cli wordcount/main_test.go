@@ -0,0 +1,49 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestCountStats(t *testing.T) {
+	tests := []struct {
+		name                string
+		input               string
+		lines, words, chars int
+	}{
+		{"empty", "", 0, 0, 0},
+		{"no final newline", "hello world", 0, 2, 11},
+		{"multi-line", "one two\nthree\n\nfour five six\n", 4, 6, 29},
+		{"accents are one char", "café crème\nnaïve\n", 2, 3, 17},
+		{"cjk and emoji", "日本語 テキスト\n👋 🌍\n", 2, 4, 13},
+		{"unicode spaces", "a\u00a0b\u2003c\n", 1, 3, 6},
+		{"only spaces", "  \t \n", 1, 0, 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines, words, chars, err := countStats(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if lines != tt.lines || words != tt.words || chars != tt.chars {
+				t.Errorf("countStats(%q) = %d, %d, %d, want %d, %d, %d",
+					tt.input, lines, words, chars, tt.lines, tt.words, tt.chars)
+			}
+		})
+	}
+}
+
+func TestCountStatsReadError(t *testing.T) {
+	boom := errors.New("boom")
+	r := io.MultiReader(strings.NewReader("one two\n"), iotest.ErrReader(boom))
+	lines, words, _, err := countStats(r)
+	if !errors.Is(err, boom) {
+		t.Fatalf("err = %v, want %v", err, boom)
+	}
+	if lines != 1 || words != 2 {
+		t.Errorf("counts before the error = %d lines, %d words, want 1, 2", lines, words)
+	}
+}
@@ -0,0 +1,134 @@
+/*
+A pipeline is a chain of stages joined by channels. Each stage is a goroutine
+that reads from the channel before it, does some work and writes to its own
+output channel:
+
+	generator -> square -> merge -> main
+
+Two rules keep a pipeline from getting stuck:
+  - every stage closes its output channel when it is done, so the range loop
+    in the next stage ends;
+  - every send also watches a done channel. When the consumer stops early it
+    closes done, and each stage returns instead of blocking forever on a send
+    that nobody will receive. A goroutine blocked like that is a "leak": it is
+    never freed for as long as the program runs.
+*/
+package main
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// running counts the stage goroutines that have not returned yet, so main can
+// check that none of them leaked.
+var running atomic.Int64
+
+// generator sends nums one by one on the returned channel.
+func generator(done <-chan struct{}, nums ...int) <-chan int {
+	out := make(chan int)
+	running.Add(1)
+	go func() {
+		defer running.Add(-1)
+		defer close(out)
+		for _, n := range nums {
+			select {
+			case out <- n:
+			case <-done:
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// square sends the square of every number read from in.
+func square(done <-chan struct{}, in <-chan int) <-chan int {
+	out := make(chan int)
+	running.Add(1)
+	go func() {
+		defer running.Add(-1)
+		defer close(out)
+		for n := range in {
+			select {
+			case out <- n * n:
+			case <-done:
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// merge fans in: it copies the values of all cs into a single channel, which is
+// closed once every input channel has been closed.
+func merge(done <-chan struct{}, cs ...<-chan int) <-chan int {
+	out := make(chan int)
+	var wg sync.WaitGroup
+
+	copyFrom := func(c <-chan int) {
+		defer running.Add(-1)
+		defer wg.Done()
+		for n := range c {
+			select {
+			case out <- n:
+			case <-done:
+				return
+			}
+		}
+	}
+
+	wg.Add(len(cs))
+	running.Add(int64(len(cs)))
+	for _, c := range cs {
+		go copyFrom(c)
+	}
+
+	running.Add(1)
+	go func() {
+		defer running.Add(-1)
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
+
+// waitForStages waits up to a second for every stage goroutine to return and
+// reports how many are still running.
+func waitForStages() int64 {
+	deadline := time.Now().Add(time.Second)
+	for running.Load() > 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	return running.Load()
+}
+
+func main() {
+	done := make(chan struct{})
+	in := generator(done, 1, 2, 3, 4, 5, 6)
+
+	// Fan out: two square stages read from the same generator.
+	results := merge(done, square(done, in), square(done, in))
+
+	// The two square stages run at the same time, so the values arrive in any
+	// order. Sort them to print something stable.
+	var all []int
+	for n := range results {
+		all = append(all, n)
+	}
+	sort.Ints(all)
+	close(done)
+	fmt.Println("All squares:", all)
+	fmt.Println("Goroutines still running:", waitForStages())
+
+	// This time stop after the first two values and let done clean up.
+	done = make(chan struct{})
+	in = generator(done, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10)
+	results = merge(done, square(done, in), square(done, in))
+	fmt.Println("First:", <-results, "second:", <-results)
+	close(done)
+	fmt.Println("Goroutines still running after stopping early:", waitForStages())
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestPipelineSquares(t *testing.T) {
+	tests := []struct {
+		name    string
+		nums    []int
+		workers int
+		want    []int
+	}{
+		{"no input", nil, 2, nil},
+		{"one worker", []int{3, 1, 2}, 1, []int{1, 4, 9}},
+		{"fan out", []int{1, 2, 3, 4, 5, 6}, 2, []int{1, 4, 9, 16, 25, 36}},
+		{"more workers than values", []int{-2, 7}, 4, []int{4, 49}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			done := make(chan struct{})
+			defer close(done)
+
+			in := generator(done, tt.nums...)
+			stages := make([]<-chan int, tt.workers)
+			for i := range stages {
+				stages[i] = square(done, in)
+			}
+
+			var got []int
+			for n := range merge(done, stages...) {
+				got = append(got, n)
+			}
+			sort.Ints(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if n := waitForStages(); n != 0 {
+		t.Errorf("%d stage goroutines still running", n)
+	}
+}
+
+func TestPipelineStopsEarly(t *testing.T) {
+	done := make(chan struct{})
+	in := generator(done, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10)
+	results := merge(done, square(done, in), square(done, in))
+	<-results
+	<-results
+	close(done)
+
+	if n := waitForStages(); n != 0 {
+		t.Errorf("%d stage goroutines still running after done was closed", n)
+	}
+}
@@ -0,0 +1,81 @@
+/*
+defer schedules a call to run when the surrounding function returns, even if
+it returns because of a panic. Deferred calls run in reverse order (last in,
+first out), which is exactly the order resources should be released in.
+
+A panic stops the normal flow of the program. recover, called from a deferred
+function, stops the panic and returns the value it was started with. It is
+meant for the few cases where a crash can be turned into an ordinary error;
+everything else should be left to panic.
+*/
+package main
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+)
+
+// ErrDivideByZero is returned by safeDivide instead of crashing.
+var ErrDivideByZero = errors.New("division by zero")
+
+// safeDivide returns a / b. The division panics when b is 0, and the deferred
+// function turns that one panic into ErrDivideByZero. Any other panic is not
+// ours to handle, so it is started again with the same value.
+func safeDivide(a, b int) (result int, err error) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			return // no panic, nothing to do
+		}
+		// The runtime has no exported type for this error, only its message.
+		if re, ok := r.(runtime.Error); ok && re.Error() == "runtime error: integer divide by zero" {
+			// result and err are named, so the deferred function can still
+			// change what safeDivide returns.
+			err = fmt.Errorf("safeDivide(%d, %d): %w", a, b, ErrDivideByZero)
+			return
+		}
+		panic(r)
+	}()
+	return a / b, nil
+}
+
+// resource pretends to be something that must be closed, like a file.
+type resource struct {
+	name string
+	log  *[]string
+}
+
+func open(name string, log *[]string) *resource {
+	*log = append(*log, "open "+name)
+	return &resource{name: name, log: log}
+}
+
+func (r *resource) Close() {
+	*r.log = append(*r.log, "close "+r.name)
+}
+
+// useResources opens three resources and defers closing each one. It returns
+// the order of the events: they close in the reverse order they were opened.
+func useResources() []string {
+	var log []string
+	func() {
+		db := open("database", &log)
+		defer db.Close()
+		file := open("file", &log)
+		defer file.Close()
+		conn := open("connection", &log)
+		defer conn.Close()
+		log = append(log, "working")
+	}()
+	return log
+}
+
+func main() {
+	fmt.Println(safeDivide(10, 3))
+	fmt.Println(safeDivide(1, 0))
+
+	for _, event := range useResources() {
+		fmt.Println(event)
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSafeDivide(t *testing.T) {
+	tests := []struct {
+		a, b int
+		want int
+	}{
+		{10, 3, 3},
+		{-9, 3, -3},
+		{0, 5, 0},
+		{7, -2, -3},
+	}
+	for _, tt := range tests {
+		got, err := safeDivide(tt.a, tt.b)
+		if err != nil || got != tt.want {
+			t.Errorf("safeDivide(%d, %d) = %d, %v, want %d", tt.a, tt.b, got, err, tt.want)
+		}
+	}
+}
+
+func TestSafeDivideByZero(t *testing.T) {
+	for _, a := range []int{1, 0, -4} {
+		got, err := safeDivide(a, 0)
+		if !errors.Is(err, ErrDivideByZero) {
+			t.Errorf("safeDivide(%d, 0) err = %v, want ErrDivideByZero", a, err)
+		}
+		if got != 0 {
+			t.Errorf("safeDivide(%d, 0) = %d, want 0", a, got)
+		}
+	}
+}
+
+func TestUseResourcesClosesInReverse(t *testing.T) {
+	want := []string{
+		"open database", "open file", "open connection",
+		"working",
+		"close connection", "close file", "close database",
+	}
+	if got := useResources(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
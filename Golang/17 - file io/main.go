@@ -0,0 +1,139 @@
+/*
+Reading, writing and copying files with the os, bufio and io packages.
+
+Every file that is opened must be closed, and the simplest way to never forget
+is to defer Close() right after the open succeeds. For files we write to,
+Close can fail too (the last data is often only written to disk then), so its
+error is returned instead of thrown away.
+*/
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// closeFile closes f and stores the error in *err, unless an earlier error is
+// already there. Use it as: defer closeFile(f, &err)
+func closeFile(f *os.File, err *error) {
+	if cerr := f.Close(); cerr != nil && *err == nil {
+		*err = cerr
+	}
+}
+
+// WriteLines creates (or truncates) the file at path and writes one line per
+// element of lines.
+func WriteLines(path string, lines []string) (err error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer closeFile(f, &err)
+
+	w := bufio.NewWriter(f)
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	// bufio.Writer keeps data in memory until Flush, don't forget it.
+	return w.Flush()
+}
+
+// ReadLines returns the lines of the file at path, without the newlines.
+//
+// bufio.Scanner refuses lines longer than bufio.MaxScanTokenSize (64 KB) and
+// stops with bufio.ErrTooLong. Use ReadLinesMax for files with longer lines.
+func ReadLines(path string) ([]string, error) {
+	return ReadLinesMax(path, bufio.MaxScanTokenSize)
+}
+
+// ReadLinesMax is ReadLines with a custom limit, in bytes, for the longest line.
+func ReadLinesMax(path string, maxLineSize int) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	// Only reading: there is nothing useful to do with a Close error here.
+	defer f.Close()
+
+	var lines []string
+	sc := bufio.NewScanner(f)
+	// Start with a small buffer, the scanner grows it up to maxLineSize.
+	sc.Buffer(make([]byte, 0, min(4096, maxLineSize)), maxLineSize)
+	for sc.Scan() {
+		lines = append(lines, sc.Text())
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return lines, nil
+}
+
+// CopyFile copies the file src to dst and returns the number of bytes copied.
+func CopyFile(dst, src string) (written int64, err error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return 0, err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return 0, err
+	}
+	defer closeFile(out, &err)
+
+	return io.Copy(out, in)
+}
+
+func main() {
+	dir, err := os.MkdirTemp("", "fileio")
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	original := filepath.Join(dir, "lines.txt")
+	if err := WriteLines(original, []string{"first line", "second line", "third line"}); err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+
+	backup := filepath.Join(dir, "backup.txt")
+	n, err := CopyFile(backup, original)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	fmt.Println("Copied", n, "bytes")
+
+	lines, err := ReadLines(backup)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	for i, line := range lines {
+		fmt.Printf("%d: %s\n", i+1, line)
+	}
+
+	// A single 100 KB line is too long for the default scanner buffer.
+	long := filepath.Join(dir, "long.txt")
+	if err := WriteLines(long, []string{strings.Repeat("x", 100*1024)}); err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	_, err = ReadLines(long)
+	fmt.Println("ReadLines:", err)
+	lines, err = ReadLinesMax(long, 1024*1024)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	fmt.Println("ReadLinesMax:", len(lines[0]), "characters")
+}
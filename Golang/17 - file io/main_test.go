@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWriteReadLines(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+	}{
+		{"none", nil},
+		{"one", []string{"only"}},
+		{"several", []string{"first line", "", "third line"}},
+		{"unicode", []string{"héllo", "日本"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "lines.txt")
+			if err := WriteLines(path, tt.lines); err != nil {
+				t.Fatalf("WriteLines: %v", err)
+			}
+			got, err := ReadLines(path)
+			if err != nil {
+				t.Fatalf("ReadLines: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.lines) {
+				t.Errorf("ReadLines = %q, want %q", got, tt.lines)
+			}
+		})
+	}
+}
+
+func TestReadLinesTooLong(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "long.txt")
+	long := strings.Repeat("x", 100*1024)
+	if err := WriteLines(path, []string{long}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ReadLines(path); !errors.Is(err, bufio.ErrTooLong) {
+		t.Errorf("ReadLines err = %v, want bufio.ErrTooLong", err)
+	}
+	lines, err := ReadLinesMax(path, 1024*1024)
+	if err != nil {
+		t.Fatalf("ReadLinesMax: %v", err)
+	}
+	if len(lines) != 1 || lines[0] != long {
+		t.Errorf("ReadLinesMax returned %d lines", len(lines))
+	}
+}
+
+func TestReadLinesMissingFile(t *testing.T) {
+	_, err := ReadLines(filepath.Join(t.TempDir(), "missing.txt"))
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("err = %v, want fs.ErrNotExist", err)
+	}
+}
+
+func TestCopyFile(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "src.txt")
+	dst := filepath.Join(dir, "dst.txt")
+	if err := WriteLines(src, []string{"a", "bc"}); err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := CopyFile(dst, src)
+	if err != nil {
+		t.Fatalf("CopyFile: %v", err)
+	}
+	if n != 5 {
+		t.Errorf("CopyFile copied %d bytes, want 5", n)
+	}
+	data, err := os.ReadFile(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "a\nbc\n" {
+		t.Errorf("dst = %q, want %q", data, "a\nbc\n")
+	}
+}
+
+func TestCopyFileMissingSource(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := CopyFile(filepath.Join(dir, "dst.txt"), filepath.Join(dir, "missing.txt")); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("err = %v, want fs.ErrNotExist", err)
+	}
+}
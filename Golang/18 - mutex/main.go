@@ -0,0 +1,75 @@
+/*
+When many goroutines change the same variable at the same time, some updates
+get lost: counts[key]++ is really "read, add one, write back", and two
+goroutines can read the same old value. This is called a data race.
+
+A sync.Mutex fixes it: only one goroutine at a time can hold the lock, so the
+read-add-write happens as one step. Run the example with the race detector
+to check that no race is left:
+
+	go run -race main.go
+	go test -race *.go
+*/
+package main
+
+import (
+	"fmt"
+	"sync"
+)
+
+// SafeCounter counts per key and can be used by many goroutines at once.
+// The zero value is ready to use.
+type SafeCounter struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+// Inc adds one to the counter of key.
+func (c *SafeCounter) Inc(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.counts == nil {
+		c.counts = make(map[string]int)
+	}
+	c.counts[key]++
+}
+
+// Value returns the current counter of key. Reading needs the lock too,
+// otherwise it could race with an Inc happening at the same moment.
+func (c *SafeCounter) Value(key string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.counts[key]
+}
+
+// This is the same counter without the mutex. Used from many goroutines, the
+// final value is often less than expected, the program can even crash with
+// "fatal error: concurrent map writes", and go run -race reports
+// "WARNING: DATA RACE" pointing at the counts[key]++ line.
+//
+// type UnsafeCounter struct {
+// 	counts map[string]int
+// }
+//
+// func (c *UnsafeCounter) Inc(key string) {
+// 	c.counts[key]++
+// }
+
+func main() {
+	var counter SafeCounter
+	var wg sync.WaitGroup
+
+	for i := 0; i < 1000; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			counter.Inc("visits")
+		}()
+	}
+	wg.Wait()
+
+	fmt.Println("visits:", counter.Value("visits"))
+	if counter.Value("visits") != 1000 {
+		fmt.Println("Some increments were lost!")
+	}
+}
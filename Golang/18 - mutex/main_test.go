@@ -0,0 +1,50 @@
+package main
+
+import (
+	"sync"
+	"testing"
+)
+
+// Run with go test -race *.go to also check that SafeCounter has no data race.
+func TestSafeCounterConcurrent(t *testing.T) {
+	const goroutines, perGoroutine = 50, 200
+	keys := []string{"a", "b", "c"}
+
+	var counter SafeCounter
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < perGoroutine; j++ {
+				counter.Inc(keys[j%len(keys)])
+				// Reads run alongside the writes.
+				_ = counter.Value(keys[0])
+			}
+		}()
+	}
+	wg.Wait()
+
+	total := 0
+	for _, k := range keys {
+		total += counter.Value(k)
+	}
+	if want := goroutines * perGoroutine; total != want {
+		t.Errorf("total = %d, want %d", total, want)
+	}
+	if got, want := counter.Value("a"), goroutines*67; got != want {
+		t.Errorf(`Value("a") = %d, want %d`, got, want)
+	}
+}
+
+func TestSafeCounterZeroValue(t *testing.T) {
+	var counter SafeCounter
+	if got := counter.Value("missing"); got != 0 {
+		t.Errorf("Value on a zero counter = %d, want 0", got)
+	}
+	counter.Inc("x")
+	counter.Inc("x")
+	if got := counter.Value("x"); got != 2 {
+		t.Errorf(`Value("x") = %d, want 2`, got)
+	}
+}
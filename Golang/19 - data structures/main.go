@@ -0,0 +1,30 @@
+package main
+
+import "fmt"
+
+func main() {
+	var stack Stack[string]
+	for _, plate := range []string{"red", "green", "blue"} {
+		stack.Push(plate)
+	}
+	fmt.Println("Stack size:", stack.Len())
+	for stack.Len() > 0 {
+		plate, _ := stack.Pop()
+		fmt.Println("pop", plate)
+	}
+	// Popping an empty stack is not an error, ok just reports false.
+	plate, ok := stack.Pop()
+	fmt.Printf("empty pop: %q %v\n", plate, ok)
+
+	var queue Queue[int]
+	for i := 1; i <= 5; i++ {
+		queue.Enqueue(i * 10)
+	}
+	fmt.Println("Queue size:", queue.Len())
+	for queue.Len() > 0 {
+		n, _ := queue.Dequeue()
+		fmt.Println("dequeue", n)
+	}
+	n, ok := queue.Dequeue()
+	fmt.Println("empty dequeue:", n, ok)
+}
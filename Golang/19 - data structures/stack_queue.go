@@ -0,0 +1,78 @@
+/*
+A stack is last in, first out (LIFO), like a pile of plates.
+A queue is first in, first out (FIFO), like a line at the supermarket.
+Both are generic, so the same code stores ints, strings or anything else.
+*/
+package main
+
+// Stack is a LIFO container. The zero value is an empty stack.
+type Stack[T any] struct {
+	items []T
+}
+
+// Push puts v on top of the stack.
+func (s *Stack[T]) Push(v T) {
+	s.items = append(s.items, v)
+}
+
+// Pop removes and returns the top element. On an empty stack it returns the
+// zero value of T and false.
+func (s *Stack[T]) Pop() (T, bool) {
+	var zero T
+	if len(s.items) == 0 {
+		return zero, false
+	}
+	last := len(s.items) - 1
+	v := s.items[last]
+	// Clear the slot so the stack does not keep the value alive.
+	s.items[last] = zero
+	s.items = s.items[:last]
+	return v, true
+}
+
+// Len returns the number of elements in the stack.
+func (s *Stack[T]) Len() int {
+	return len(s.items)
+}
+
+// Queue is a FIFO container. The zero value is an empty queue.
+//
+// Dequeue does not use items = items[1:]: that only hides the first element,
+// the backing array keeps it in memory and never gets smaller. Instead head
+// marks the first element still in the queue, and the slice is compacted once
+// more than half of it is dead space. Every element is moved at most once per
+// compaction, so Dequeue is O(1) on average.
+type Queue[T any] struct {
+	items []T
+	head  int
+}
+
+// Enqueue adds v at the back of the queue.
+func (q *Queue[T]) Enqueue(v T) {
+	q.items = append(q.items, v)
+}
+
+// Dequeue removes and returns the element at the front. On an empty queue it
+// returns the zero value of T and false.
+func (q *Queue[T]) Dequeue() (T, bool) {
+	var zero T
+	if q.head == len(q.items) {
+		return zero, false
+	}
+	v := q.items[q.head]
+	q.items[q.head] = zero
+	q.head++
+
+	if q.head > len(q.items)/2 {
+		n := copy(q.items, q.items[q.head:])
+		clear(q.items[n:])
+		q.items = q.items[:n]
+		q.head = 0
+	}
+	return v, true
+}
+
+// Len returns the number of elements in the queue.
+func (q *Queue[T]) Len() int {
+	return len(q.items) - q.head
+}
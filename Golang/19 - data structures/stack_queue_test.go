@@ -0,0 +1,134 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestStack(t *testing.T) {
+	tests := []struct {
+		name string
+		push []int
+		pops int
+		want []int // popped values, in order
+		left int
+	}{
+		{"empty", nil, 1, nil, 0},
+		{"last in first out", []int{1, 2, 3}, 3, []int{3, 2, 1}, 0},
+		{"partly popped", []int{1, 2, 3}, 2, []int{3, 2}, 1},
+		{"pop past empty", []int{1}, 3, []int{1}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var s Stack[int]
+			for _, v := range tt.push {
+				s.Push(v)
+			}
+			var got []int
+			for i := 0; i < tt.pops; i++ {
+				if v, ok := s.Pop(); ok {
+					got = append(got, v)
+				}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("popped %v, want %v", got, tt.want)
+			}
+			if s.Len() != tt.left {
+				t.Errorf("Len = %d, want %d", s.Len(), tt.left)
+			}
+		})
+	}
+}
+
+func TestQueue(t *testing.T) {
+	tests := []struct {
+		name    string
+		enqueue []int
+		ops     int
+		want    []int // dequeued values, in order
+		left    int
+	}{
+		{"empty", nil, 1, nil, 0},
+		{"first in first out", []int{1, 2, 3}, 3, []int{1, 2, 3}, 0},
+		{"partly dequeued", []int{1, 2, 3, 4, 5}, 3, []int{1, 2, 3}, 2},
+		{"dequeue past empty", []int{7}, 2, []int{7}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var q Queue[int]
+			for _, v := range tt.enqueue {
+				q.Enqueue(v)
+			}
+			var got []int
+			for i := 0; i < tt.ops; i++ {
+				if v, ok := q.Dequeue(); ok {
+					got = append(got, v)
+				}
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("dequeued %v, want %v", got, tt.want)
+			}
+			if q.Len() != tt.left {
+				t.Errorf("Len = %d, want %d", q.Len(), tt.left)
+			}
+		})
+	}
+}
+
+// Mixing Enqueue and Dequeue keeps the order across compactions.
+func TestQueueInterleaved(t *testing.T) {
+	var q Queue[int]
+	next, want := 0, 0
+	for round := 0; round < 100; round++ {
+		for i := 0; i < 3; i++ {
+			q.Enqueue(next)
+			next++
+		}
+		for i := 0; i < 2; i++ {
+			v, ok := q.Dequeue()
+			if !ok || v != want {
+				t.Fatalf("round %d: Dequeue = %d, %v, want %d", round, v, ok, want)
+			}
+			want++
+		}
+	}
+	if q.Len() != next-want {
+		t.Errorf("Len = %d, want %d", q.Len(), next-want)
+	}
+	if len(q.items) > 2*q.Len() {
+		t.Errorf("backing slice of %d for %d elements was not compacted", len(q.items), q.Len())
+	}
+}
+
+// Pushing 0..99 gives them back in reverse from the stack and in order from
+// the queue, and both end up empty.
+func TestHundredElements(t *testing.T) {
+	const n = 100
+	var s Stack[int]
+	var q Queue[int]
+	for i := 0; i < n; i++ {
+		s.Push(i)
+		q.Enqueue(i)
+	}
+	if s.Len() != n || q.Len() != n {
+		t.Fatalf("Len = %d (stack), %d (queue), want %d", s.Len(), q.Len(), n)
+	}
+
+	for want := n - 1; want >= 0; want-- {
+		if v, ok := s.Pop(); !ok || v != want {
+			t.Fatalf("Pop = %d, %v, want %d", v, ok, want)
+		}
+	}
+	for want := 0; want < n; want++ {
+		if v, ok := q.Dequeue(); !ok || v != want {
+			t.Fatalf("Dequeue = %d, %v, want %d", v, ok, want)
+		}
+	}
+
+	if _, ok := s.Pop(); ok {
+		t.Error("Pop on an empty stack returned ok")
+	}
+	if _, ok := q.Dequeue(); ok {
+		t.Error("Dequeue on an empty queue returned ok")
+	}
+}
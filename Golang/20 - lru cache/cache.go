@@ -0,0 +1,86 @@
+/*
+An LRU (least recently used) cache keeps at most a fixed number of entries.
+When it is full and a new key arrives, the entry that was used the longest
+time ago is thrown away.
+
+Two structures work together so that every operation is O(1):
+  - a container/list doubly linked list keeps the entries in order of use,
+    the most recent at the front and the next one to evict at the back;
+  - a map finds the list element of a key without walking the list.
+
+A sync.Mutex guards both, so the cache can be shared by many goroutines.
+Note that Get needs the full lock too, not a read lock: it moves the entry to
+the front of the list, which is a write.
+*/
+package main
+
+import (
+	"container/list"
+	"sync"
+)
+
+// entry is what the list elements hold. The key is kept too, so evicting the
+// back element can also delete it from the map.
+type entry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// LRU is a fixed-size, least recently used cache. Create it with NewLRU.
+type LRU[K comparable, V any] struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	items    map[K]*list.Element
+}
+
+// NewLRU returns an empty cache holding up to capacity entries.
+// A capacity smaller than 1 is treated as 1.
+func NewLRU[K comparable, V any](capacity int) *LRU[K, V] {
+	return &LRU[K, V]{
+		capacity: max(capacity, 1),
+		order:    list.New(),
+		items:    make(map[K]*list.Element),
+	}
+}
+
+// Get returns the value stored for k and marks it as the most recently used.
+func (c *LRU[K, V]) Get(k K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[k]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.order.MoveToFront(el)
+	return el.Value.(*entry[K, V]).value, true
+}
+
+// Put stores v for k, marking it as the most recently used. If the cache is
+// over capacity afterwards, the least recently used entry is evicted.
+func (c *LRU[K, V]) Put(k K, v V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[k]; ok {
+		el.Value.(*entry[K, V]).value = v
+		c.order.MoveToFront(el)
+		return
+	}
+
+	c.items[k] = c.order.PushFront(&entry[K, V]{key: k, value: v})
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*entry[K, V]).key)
+	}
+}
+
+// Len returns the number of entries in the cache.
+func (c *LRU[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// op is one step of a test: a Put when put is true, otherwise a Get that
+// must return want and found.
+type op struct {
+	put   bool
+	key   string
+	value int
+	want  int
+	found bool
+}
+
+func put(k string, v int) op    { return op{put: true, key: k, value: v} }
+func get(k string, want int) op { return op{key: k, want: want, found: true} }
+func missing(k string) op       { return op{key: k} }
+
+func TestLRU(t *testing.T) {
+	tests := []struct {
+		name     string
+		capacity int
+		ops      []op
+		wantLen  int
+	}{
+		{"get from empty", 2, []op{missing("a")}, 0},
+		{"put and get", 2, []op{put("a", 1), get("a", 1)}, 1},
+		{"evicts oldest", 2, []op{put("a", 1), put("b", 2), put("c", 3),
+			missing("a"), get("b", 2), get("c", 3)}, 2},
+		{"get refreshes", 2, []op{put("a", 1), put("b", 2), get("a", 1), put("c", 3),
+			get("a", 1), missing("b")}, 2},
+		{"update refreshes", 2, []op{put("a", 1), put("b", 2), put("a", 10), put("c", 3),
+			get("a", 10), missing("b")}, 2},
+		{"capacity below 1", 0, []op{put("a", 1), put("b", 2), missing("a"), get("b", 2)}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewLRU[string, int](tt.capacity)
+			for i, o := range tt.ops {
+				if o.put {
+					c.Put(o.key, o.value)
+					continue
+				}
+				v, ok := c.Get(o.key)
+				if ok != o.found || v != o.want {
+					t.Errorf("op %d: Get(%q) = %d, %v, want %d, %v", i, o.key, v, ok, o.want, o.found)
+				}
+			}
+			if c.Len() != tt.wantLen {
+				t.Errorf("Len = %d, want %d", c.Len(), tt.wantLen)
+			}
+		})
+	}
+}
+
+// Run with -race: many goroutines share one cache.
+func TestLRUConcurrent(t *testing.T) {
+	c := NewLRU[string, int](10)
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				k := fmt.Sprint(i % 20)
+				c.Put(k, i)
+				c.Get(k)
+			}
+		}()
+	}
+	wg.Wait()
+	if c.Len() != 10 {
+		t.Errorf("Len = %d, want 10", c.Len())
+	}
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+)
+
+func main() {
+	cache := NewLRU[string, int](2)
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+
+	// Reading "a" makes it the most recently used, so "b" goes first.
+	cache.Get("a")
+	cache.Put("c", 3)
+
+	for _, k := range []string{"a", "b", "c"} {
+		v, ok := cache.Get(k)
+		fmt.Printf("%s: %d %v\n", k, v, ok)
+	}
+	fmt.Println("entries:", cache.Len())
+
+	// Many goroutines can use the same cache at once.
+	shared := NewLRU[int, int](10)
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			shared.Put(i%20, i)
+			shared.Get(i % 7)
+		}()
+	}
+	wg.Wait()
+	fmt.Println("shared entries:", shared.Len())
+}
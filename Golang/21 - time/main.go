@@ -0,0 +1,71 @@
+/*
+Go does not use patterns like yyyy-MM-dd HH:mm:ss to format dates. Instead a
+layout is the reference time
+
+	Mon Jan 2 15:04:05 MST 2006
+
+written the way you want your dates to look. Each part is a placeholder:
+2006 is the year, 01 the month, 02 the day, 15 the hour (24h clock), 04 the
+minutes and 05 the seconds. An easy way to remember it is 1 2 3 4 5 6 7:
+month 1, day 2, hour 3 (15), minute 4, second 5, year 6 and zone -7.
+
+Coming from other languages, "yyyy/MM/dd" is a common mistake: Go does not
+complain, it just prints those letters as they are, so the date never shows
+up. Using "2006-02-01" by accident swaps day and month, which is even harder
+to spot because it still looks like a date.
+*/
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// Layout is the format used by FormatLocal and ParseInZone. The standard
+// library also has ready-made layouts, like time.DateTime which is the same.
+const Layout = "2006-01-02 15:04:05"
+
+// FormatLocal returns the date and clock time of t as seen in t's own time
+// zone, without the zone name. Call t.Local() first to show it in the
+// computer's time zone instead.
+func FormatLocal(t time.Time) string {
+	return t.Format(Layout)
+}
+
+// ParseInZone reads s, written with Layout, as a time in the IANA time zone
+// named zone, for example "Europe/Rome" or "America/New_York".
+func ParseInZone(s, zone string) (time.Time, error) {
+	loc, err := time.LoadLocation(zone)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parse in zone: %w", err)
+	}
+	t, err := time.ParseInLocation(Layout, s, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parse in zone: %w", err)
+	}
+	return t, nil
+}
+
+func main() {
+	// A fixed time, so the example prints the same thing every time.
+	launch := time.Date(1969, time.July, 20, 20, 17, 40, 0, time.UTC)
+	fmt.Println("Layout:     ", FormatLocal(launch))
+	fmt.Println("Wrong style:", launch.Format("yyyy/MM/dd HH:mm"))
+	fmt.Println("RFC 3339:   ", launch.Format(time.RFC3339))
+	fmt.Println("Custom:     ", launch.Format("Monday, 2 January 2006 at 3:04 PM"))
+
+	// The same wall clock time is a different instant in every zone.
+	rome, err := ParseInZone("2024-03-10 09:30:00", "Europe/Rome")
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	fmt.Println("Rome:", rome, "- in UTC:", FormatLocal(rome.UTC()))
+
+	if _, err := ParseInZone("2024-03-10 09:30:00", "Mars/Olympus_Mons"); err != nil {
+		fmt.Println("Error:", err)
+	}
+	if _, err := ParseInZone("10/03/2024 09:30", "Europe/Rome"); err != nil {
+		fmt.Println("Error:", err)
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+	// Embeds the time zone database so the tests do not depend on the system.
+	_ "time/tzdata"
+)
+
+func TestFormatLocal(t *testing.T) {
+	tests := []struct {
+		name string
+		in   time.Time
+		want string
+	}{
+		{"utc", time.Date(1969, time.July, 20, 20, 17, 40, 0, time.UTC), "1969-07-20 20:17:40"},
+		{"single digits padded", time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC), "2024-01-02 03:04:05"},
+		{"fixed zone kept", time.Date(2024, time.March, 10, 9, 30, 0, 0, time.FixedZone("UTC+2", 2*60*60)), "2024-03-10 09:30:00"},
+		{"nanoseconds dropped", time.Date(2000, time.December, 31, 23, 59, 59, 999, time.UTC), "2000-12-31 23:59:59"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatLocal(tt.in); got != tt.want {
+				t.Errorf("FormatLocal = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseInZone(t *testing.T) {
+	tests := []struct {
+		name, in, zone string
+		wantUTC        string
+	}{
+		{"rome winter", "2024-03-10 09:30:00", "Europe/Rome", "2024-03-10 08:30:00"},
+		{"rome summer", "2024-07-10 09:30:00", "Europe/Rome", "2024-07-10 07:30:00"},
+		{"new york", "2024-03-10 09:30:00", "America/New_York", "2024-03-10 13:30:00"},
+		{"utc", "1969-07-20 20:17:40", "UTC", "1969-07-20 20:17:40"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseInZone(tt.in, tt.zone)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if FormatLocal(got) != tt.in {
+				t.Errorf("wall clock = %q, want %q", FormatLocal(got), tt.in)
+			}
+			if utc := FormatLocal(got.UTC()); utc != tt.wantUTC {
+				t.Errorf("in UTC = %q, want %q", utc, tt.wantUTC)
+			}
+		})
+	}
+}
+
+func TestParseInZoneErrors(t *testing.T) {
+	if _, err := ParseInZone("2024-03-10 09:30:00", "Mars/Olympus_Mons"); err == nil {
+		t.Error("unknown zone: want an error")
+	}
+	_, err := ParseInZone("10/03/2024 09:30", "Europe/Rome")
+	var parseErr *time.ParseError
+	if !errors.As(err, &parseErr) {
+		t.Errorf("bad layout: err = %v, want a *time.ParseError", err)
+	}
+}
@@ -0,0 +1,66 @@
+/*
+The regexp package finds and replaces text with regular expressions.
+
+Compiling a pattern takes time, so the patterns live in package variables and
+are compiled once when the program starts. regexp.MustCompile panics on a bad
+pattern, which is fine here: the patterns are fixed, and a typo shows up the
+first time the program runs.
+
+(?P<name>...) is a named capture group: it remembers the text it matched under
+a name, so the code can ask for "year" instead of counting parentheses.
+*/
+package main
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// isoDate matches dates like 2024-03-10. \b makes sure the date is not part of
+// a longer number, so "12024-03-10" or "2024-03-100" are not dates.
+var isoDate = regexp.MustCompile(`\b(?P<year>\d{4})-(?P<month>0[1-9]|1[0-2])-(?P<day>0[1-9]|[12]\d|3[01])\b`)
+
+// email matches addresses like ada.lovelace+notes@example.co.uk. The domain
+// needs at least one dot and a top level part of two letters or more, so
+// "root@localhost" or "me@site." are left alone. A dot ending a sentence
+// right after an address is not part of it.
+var email = regexp.MustCompile(`(?P<user>[A-Za-z0-9._%+-]+)@(?P<domain>[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)*\.[A-Za-z]{2,})\b`)
+
+// ExtractDates returns every ISO date found in text, in order. Regular
+// expressions without ^ or $ do not care about newlines, so text can have
+// any number of lines.
+func ExtractDates(text string) []string {
+	year := isoDate.SubexpIndex("year")
+	month := isoDate.SubexpIndex("month")
+	day := isoDate.SubexpIndex("day")
+
+	dates := []string{}
+	for _, m := range isoDate.FindAllStringSubmatch(text, -1) {
+		dates = append(dates, m[year]+"-"+m[month]+"-"+m[day])
+	}
+	return dates
+}
+
+// RedactEmails replaces every email address in text with "[redacted]".
+func RedactEmails(text string) string {
+	return email.ReplaceAllLiteralString(text, "[redacted]")
+}
+
+func main() {
+	text := `Meeting notes from 2024-03-10, written by ada@example.com.
+Send questions to grace.hopper+navy@mail.example.org or bob@test.io before 2024-04-01.
+Not dates: 2024-13-01, 12024-01-01. Not emails: root@localhost, me@site.`
+
+	fmt.Println("Dates:", ExtractDates(text))
+	fmt.Println(RedactEmails(text))
+
+	// The named groups can be read one by one too.
+	m := email.FindStringSubmatch("contact: ada@example.com")
+	for i, name := range email.SubexpNames() {
+		if name != "" {
+			fmt.Printf("%s = %s\n", name, m[i])
+		}
+	}
+
+	fmt.Println("No match:", ExtractDates("nothing to see here"))
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+const prose = `Meeting notes from 2024-03-10, written by ada@example.com.
+Send questions to grace.hopper+navy@mail.example.org or bob@test.io before 2024-04-01.
+Not dates: 2024-13-01, 12024-01-01. Not emails: root@localhost, me@site.`
+
+func TestExtractDates(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"prose", prose, []string{"2024-03-10", "2024-04-01"}},
+		{"no match", "nothing to see here", []string{}},
+		{"empty", "", []string{}},
+		{"invalid month and day", "2024-00-10 2024-02-32 2024-1-05", []string{}},
+		{"part of a longer number", "12024-03-10 2024-03-100", []string{}},
+		{"end of month", "due 2024-12-31.", []string{"2024-12-31"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ExtractDates(tt.text)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExtractDates = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactEmails(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"prose", prose, `Meeting notes from 2024-03-10, written by [redacted].
+Send questions to [redacted] or [redacted] before 2024-04-01.
+Not dates: 2024-13-01, 12024-01-01. Not emails: root@localhost, me@site.`},
+		{"no match", "no addresses here", "no addresses here"},
+		{"no tld", "root@localhost", "root@localhost"},
+		{"trailing dot", "mail me@site.", "mail me@site."},
+		{"subdomain", "(x@a.b.co.uk)", "([redacted])"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RedactEmails(tt.text); got != tt.want {
+				t.Errorf("RedactEmails =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEmailGroups(t *testing.T) {
+	m := email.FindStringSubmatch("contact: ada.lovelace+notes@example.co.uk")
+	if m == nil {
+		t.Fatal("no match")
+	}
+	if user := m[email.SubexpIndex("user")]; user != "ada.lovelace+notes" {
+		t.Errorf("user = %q", user)
+	}
+	if domain := m[email.SubexpIndex("domain")]; domain != "example.co.uk" {
+		t.Errorf("domain = %q", domain)
+	}
+}
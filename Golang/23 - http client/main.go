@@ -0,0 +1,82 @@
+/*
+Fetching JSON over HTTP with net/http.
+
+http.Get and http.DefaultClient have no timeout at all: if the server never
+answers, the program waits forever. So this example builds its own
+http.Client with a Timeout, and every request also takes a context so the
+caller can give up even sooner.
+
+To run without internet access, main starts a small test server on the local
+machine with net/http/httptest and fetches from it.
+*/
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+// client is shared by every request. A http.Client is safe to use from many
+// goroutines and reuses connections, so there is no need to make a new one.
+var client = &http.Client{Timeout: 10 * time.Second}
+
+// FetchJSON sends a GET request to url and decodes the JSON response body into
+// out, which must be a pointer. Status codes outside 200-299 are errors.
+func FetchJSON(ctx context.Context, url string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("fetch %s: %w", url, err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("fetch %s: %w", url, err)
+	}
+	// The body must always be closed, on errors too, or the connection leaks.
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("fetch %s: unexpected status %s", url, resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("fetch %s: decoding response: %w", url, err)
+	}
+	return nil
+}
+
+type User struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func main() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id": 1, "name": "Ada"}`)
+	})
+	mux.HandleFunc("/broken", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "something went wrong", http.StatusInternalServerError)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var user User
+	if err := FetchJSON(ctx, server.URL+"/user", &user); err != nil {
+		fmt.Println("Error:", err)
+	} else {
+		fmt.Printf("Got user %+v\n", user)
+	}
+
+	if err := FetchJSON(ctx, server.URL+"/broken", &user); err != nil {
+		fmt.Println("Error:", err)
+	}
+}
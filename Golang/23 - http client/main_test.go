@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFetchJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Accept"); got != "application/json" {
+			t.Errorf("Accept = %q, want application/json", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id": 1, "name": "Ada"}`)
+	}))
+	defer server.Close()
+
+	var user User
+	if err := FetchJSON(context.Background(), server.URL, &user); err != nil {
+		t.Fatal(err)
+	}
+	if want := (User{ID: 1, Name: "Ada"}); user != want {
+		t.Errorf("user = %+v, want %+v", user, want)
+	}
+}
+
+func TestFetchJSONErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		message string
+	}{
+		{
+			"server error",
+			func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "something went wrong", http.StatusInternalServerError)
+			},
+			"unexpected status 500 Internal Server Error",
+		},
+		{
+			"not found",
+			http.NotFound,
+			"unexpected status 404 Not Found",
+		},
+		{
+			"bad json",
+			func(w http.ResponseWriter, r *http.Request) { fmt.Fprint(w, `{"id": "one"}`) },
+			"decoding response",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			var user User
+			err := FetchJSON(context.Background(), server.URL, &user)
+			if err == nil || !strings.Contains(err.Error(), tt.message) {
+				t.Errorf("err = %v, want it to contain %q", err, tt.message)
+			}
+		})
+	}
+}
+
+func TestFetchJSONContextDeadline(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	var user User
+	if err := FetchJSON(ctx, server.URL, &user); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want context.DeadlineExceeded", err)
+	}
+}
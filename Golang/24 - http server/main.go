@@ -0,0 +1,117 @@
+/*
+A small web server with net/http and two routes:
+
+	GET  /health  answers {"status":"ok"}
+	POST /echo    sends back the JSON body it receives
+
+Start it with
+
+	go run main.go -addr :8080
+
+and try it from another terminal:
+
+	curl localhost:8080/health
+	curl -d '{"hello":"world"}' localhost:8080/echo
+
+Ctrl+C (SIGINT) or SIGTERM shuts it down gracefully: the server stops
+accepting new connections but lets the requests already running finish,
+waiting at most shutdownTimeout for them.
+*/
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+const (
+	shutdownTimeout = 5 * time.Second
+	maxBodySize     = 1 << 20 // 1 MB
+)
+
+// newHandler returns the routes of the server. Keeping them apart from main
+// means they can be used without opening a real port, for example with
+// net/http/httptest.
+func newHandler() http.Handler {
+	mux := http.NewServeMux()
+	// Since Go 1.22 a pattern can start with the HTTP method.
+	mux.HandleFunc("GET /health", handleHealth)
+	mux.HandleFunc("POST /echo", handleEcho)
+	return mux
+}
+
+func handleHealth(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintln(w, `{"status":"ok"}`)
+}
+
+func handleEcho(w http.ResponseWriter, r *http.Request) {
+	// Never read an unlimited body, a client could send gigabytes.
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		http.Error(w, "body too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	if err != nil {
+		// Any other error means the client sent a broken body.
+		http.Error(w, "could not read body", http.StatusBadRequest)
+		return
+	}
+	if !json.Valid(body) {
+		http.Error(w, "body is not valid JSON", http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	flag.Parse()
+
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           newHandler(),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	// ctx is cancelled when the program receives SIGINT or SIGTERM.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errs := make(chan error, 1)
+	go func() {
+		log.Printf("listening on %s", *addr)
+		errs <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errs:
+		// The server could not even start, for example the port is taken.
+		log.Fatal(err)
+	case <-ctx.Done():
+	}
+
+	log.Print("shutting down")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Printf("shutdown: %v", err)
+	}
+	// After Shutdown, ListenAndServe returns http.ErrServerClosed.
+	if err := <-errs; !errors.Is(err, http.ErrServerClosed) {
+		log.Printf("server: %v", err)
+	}
+	log.Print("bye")
+}
@@ -0,0 +1,47 @@
+package main
+
+import "testing"
+
+func TestJoinsAgree(t *testing.T) {
+	tests := []struct {
+		name  string
+		parts []string
+		want  string
+	}{
+		{"nil", nil, ""},
+		{"empty strings", []string{"", "", ""}, ""},
+		{"one element", []string{"go"}, "go"},
+		{"many elements", []string{"a", "bc", "", "déf", "g"}, "abcdéfg"},
+		{"thousand parts", parts, joinPlus(parts)},
+	}
+	joins := map[string]func([]string) string{
+		"joinPlus":    joinPlus,
+		"joinBuilder": joinBuilder,
+		"joinJoin":    joinJoin,
+	}
+	for _, tt := range tests {
+		for name, join := range joins {
+			if got := join(tt.parts); got != tt.want {
+				t.Errorf("%s: %s = %q, want %q", tt.name, name, got, tt.want)
+			}
+		}
+	}
+}
+
+func BenchmarkJoinPlus(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		joinPlus(parts)
+	}
+}
+
+func BenchmarkJoinBuilder(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		joinBuilder(parts)
+	}
+}
+
+func BenchmarkJoinJoin(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		joinJoin(parts)
+	}
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+func main() {
+	tasks := make([]func() error, 10)
+	for i := range tasks {
+		tasks[i] = func() error {
+			time.Sleep(20 * time.Millisecond)
+			if i%4 == 0 {
+				return fmt.Errorf("task %d failed", i)
+			}
+			return nil
+		}
+	}
+
+	// 10 tasks of 20ms with 3 workers take about 80ms instead of 200ms.
+	start := time.Now()
+	errs := Run(context.Background(), tasks, 3)
+	for i, err := range errs {
+		fmt.Printf("task %d: %v\n", i, err)
+	}
+	fmt.Println("took about", time.Since(start).Round(10*time.Millisecond))
+
+	// Cancel after 30ms: the first tasks finish, the rest are never started.
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	skipped := 0
+	for _, err := range Run(ctx, tasks, 2) {
+		if errors.Is(err, context.DeadlineExceeded) {
+			skipped++
+		}
+	}
+	fmt.Println("skipped after cancel:", skipped)
+}
@@ -0,0 +1,58 @@
+/*
+A worker pool runs many tasks while keeping only a few of them running at the
+same time, for example to avoid opening a thousand connections at once.
+
+The limit is a semaphore: a buffered channel with room for `workers` values.
+Before starting a task we put a value in; when the channel is full the next
+send blocks until a running task finishes and takes its value back out.
+*/
+package main
+
+import (
+	"context"
+	"sync"
+)
+
+// Run executes tasks with at most workers of them running at once and
+// returns their errors, errs[i] being the result of tasks[i].
+//
+// When ctx is cancelled, the tasks not started yet are skipped and their
+// error is ctx.Err(). Tasks already running are not interrupted (a plain
+// func() error cannot be), but Run waits for them before returning, so no
+// goroutine is left behind.
+func Run(ctx context.Context, tasks []func() error, workers int) []error {
+	if workers < 1 {
+		workers = 1
+	}
+	errs := make([]error, len(tasks))
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+
+	for i, task := range tasks {
+		// If both cases are ready select picks one at random, so check ctx
+		// first: a cancelled Run must not start anything new.
+		if ctx.Err() == nil {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+			}
+		}
+		if err := ctx.Err(); err != nil {
+			for j := i; j < len(tasks); j++ {
+				errs[j] = err
+			}
+			break
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			// Each goroutine writes its own index only, so no lock is needed.
+			errs[i] = task()
+		}()
+	}
+
+	wg.Wait()
+	return errs
+}
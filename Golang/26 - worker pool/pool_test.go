@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunLimitsWorkers(t *testing.T) {
+	for _, workers := range []int{1, 3, 8} {
+		t.Run(fmt.Sprint(workers, " workers"), func(t *testing.T) {
+			var inFlight, maxInFlight atomic.Int64
+			tasks := make([]func() error, 20)
+			for i := range tasks {
+				tasks[i] = func() error {
+					n := inFlight.Add(1)
+					defer inFlight.Add(-1)
+					// Remember the highest number of tasks ever running together.
+					for {
+						old := maxInFlight.Load()
+						if n <= old || maxInFlight.CompareAndSwap(old, n) {
+							break
+						}
+					}
+					time.Sleep(5 * time.Millisecond)
+					return nil
+				}
+			}
+
+			for i, err := range Run(context.Background(), tasks, workers) {
+				if err != nil {
+					t.Errorf("task %d: %v", i, err)
+				}
+			}
+			if got := maxInFlight.Load(); got > int64(workers) {
+				t.Errorf("%d tasks ran at once, want at most %d", got, workers)
+			}
+		})
+	}
+}
+
+func TestRunKeepsErrorOrder(t *testing.T) {
+	tasks := make([]func() error, 10)
+	for i := range tasks {
+		tasks[i] = func() error {
+			if i%4 == 0 {
+				return fmt.Errorf("task %d failed", i)
+			}
+			return nil
+		}
+	}
+
+	errs := Run(context.Background(), tasks, 3)
+	if len(errs) != len(tasks) {
+		t.Fatalf("got %d errors, want %d", len(errs), len(tasks))
+	}
+	for i, err := range errs {
+		want := "<nil>"
+		if i%4 == 0 {
+			want = fmt.Sprintf("task %d failed", i)
+		}
+		if got := fmt.Sprint(err); got != want {
+			t.Errorf("errs[%d] = %s, want %s", i, got, want)
+		}
+	}
+}
+
+func TestRunCancelSkipsRemaining(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var mu sync.Mutex
+	var ran []int
+	tasks := make([]func() error, 5)
+	for i := range tasks {
+		tasks[i] = func() error {
+			mu.Lock()
+			ran = append(ran, i)
+			mu.Unlock()
+			// The first task cancels while it still holds the only worker, so
+			// Run sees the cancellation before it could start anything else.
+			if i == 0 {
+				cancel()
+			}
+			return nil
+		}
+	}
+
+	errs := Run(ctx, tasks, 1)
+	if errs[0] != nil {
+		t.Errorf("errs[0] = %v, want nil", errs[0])
+	}
+	for i := 1; i < len(errs); i++ {
+		if !errors.Is(errs[i], context.Canceled) {
+			t.Errorf("errs[%d] = %v, want context.Canceled", i, errs[i])
+		}
+	}
+	if len(ran) != 1 {
+		t.Errorf("tasks %v ran, want only task 0", ran)
+	}
+}
+
+func TestRunAlreadyCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var started atomic.Int64
+	tasks := []func() error{
+		func() error { started.Add(1); return nil },
+		func() error { started.Add(1); return nil },
+	}
+	for i, err := range Run(ctx, tasks, 2) {
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("errs[%d] = %v, want context.Canceled", i, err)
+		}
+	}
+	if n := started.Load(); n != 0 {
+		t.Errorf("%d tasks started after cancel", n)
+	}
+}
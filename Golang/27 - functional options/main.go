@@ -0,0 +1,81 @@
+/*
+The functional options pattern gives a constructor optional settings without
+a long list of parameters or a big config struct.
+
+Each option is a small function that changes the value being built.
+NewClient first fills in the defaults and then applies the options in the
+order they were passed, so a later option wins over an earlier one, and
+adding a new option later never breaks the code already calling NewClient.
+*/
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// Default settings used by NewClient.
+const (
+	DefaultTimeout = 30 * time.Second
+	DefaultRetries = 3
+	DefaultBaseURL = "https://api.example.com"
+)
+
+type Client struct {
+	timeout time.Duration
+	retries int
+	baseURL string
+}
+
+// Option changes one setting of a Client.
+type Option func(*Client)
+
+// WithTimeout sets how long a request may take.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = d
+	}
+}
+
+// WithRetries sets how many times a failed request is tried again.
+// Negative values make no sense and are clamped to 0, meaning no retries.
+func WithRetries(n int) Option {
+	return func(c *Client) {
+		c.retries = max(n, 0)
+	}
+}
+
+// WithBaseURL sets the address every request path is appended to.
+func WithBaseURL(s string) Option {
+	return func(c *Client) {
+		c.baseURL = s
+	}
+}
+
+// NewClient returns a Client with the default settings, changed by opts.
+func NewClient(opts ...Option) *Client {
+	c := &Client{
+		timeout: DefaultTimeout,
+		retries: DefaultRetries,
+		baseURL: DefaultBaseURL,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func (c *Client) String() string {
+	return fmt.Sprintf("Client{timeout: %v, retries: %d, baseURL: %s}", c.timeout, c.retries, c.baseURL)
+}
+
+func main() {
+	fmt.Println(NewClient())
+	fmt.Println(NewClient(WithTimeout(5*time.Second), WithBaseURL("http://localhost:8080")))
+
+	// Options run in order: the second WithRetries wins.
+	fmt.Println(NewClient(WithRetries(10), WithRetries(1)))
+
+	// A negative value becomes 0.
+	fmt.Println(NewClient(WithRetries(-5)))
+}
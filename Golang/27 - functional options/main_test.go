@@ -0,0 +1,44 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewClient(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		want Client
+	}{
+		{"defaults", nil, Client{DefaultTimeout, DefaultRetries, DefaultBaseURL}},
+		{
+			"timeout and url",
+			[]Option{WithTimeout(5 * time.Second), WithBaseURL("http://localhost:8080")},
+			Client{5 * time.Second, DefaultRetries, "http://localhost:8080"},
+		},
+		{"later option wins", []Option{WithRetries(10), WithRetries(1)}, Client{DefaultTimeout, 1, DefaultBaseURL}},
+		{
+			"override in order",
+			[]Option{WithTimeout(time.Second), WithBaseURL("a"), WithTimeout(2 * time.Second), WithBaseURL("b")},
+			Client{2 * time.Second, DefaultRetries, "b"},
+		},
+		{"negative retries clamped", []Option{WithRetries(-5)}, Client{DefaultTimeout, 0, DefaultBaseURL}},
+		{"zero retries kept", []Option{WithRetries(0)}, Client{DefaultTimeout, 0, DefaultBaseURL}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewClient(tt.opts...); *got != tt.want {
+				t.Errorf("NewClient = %v, want %v", got, &tt.want)
+			}
+		})
+	}
+}
+
+func TestClientString(t *testing.T) {
+	got := NewClient().String()
+	want := "Client{timeout: 30s, retries: 3, baseURL: https://api.example.com}"
+	if got != want {
+		t.Errorf("String = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,37 @@
+package main
+
+import "fmt"
+
+func main() {
+	numbers := NewBST(func(a, b int) bool { return a < b })
+	for _, n := range []int{50, 30, 70, 20, 40, 60, 80, 30} {
+		if !numbers.Insert(n) {
+			fmt.Println("duplicate ignored:", n)
+		}
+	}
+	fmt.Println("sorted:", numbers.InOrder())
+	fmt.Println("height:", numbers.Height())
+	fmt.Println("contains 60:", numbers.Contains(60), "contains 65:", numbers.Contains(65))
+
+	// Inserting values already in order makes a tree that is just a line.
+	line := NewBST(func(a, b int) bool { return a < b })
+	for i := 1; i <= 5; i++ {
+		line.Insert(i)
+	}
+	fmt.Println("height of a sorted insert:", line.Height())
+
+	// Strings ordered by length, then alphabetically.
+	words := NewBST(func(a, b string) bool {
+		if len(a) != len(b) {
+			return len(a) < len(b)
+		}
+		return a < b
+	})
+	for _, w := range []string{"banana", "kiwi", "apple", "fig", "cherry", "date"} {
+		words.Insert(w)
+	}
+	fmt.Println("by length:", words.InOrder())
+
+	empty := NewBST(func(a, b int) bool { return a < b })
+	fmt.Println("empty:", empty.InOrder() == nil, empty.Height())
+}
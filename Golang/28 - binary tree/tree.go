@@ -0,0 +1,94 @@
+/*
+A binary search tree keeps its values sorted: everything in the left subtree of
+a node is smaller than the node, everything in the right subtree is bigger.
+Walking the tree left, node, right (in-order) therefore visits the values in
+sorted order.
+
+The tree does not require T to have a < operator. It takes a less function
+instead, so the same code can sort numbers, strings by length, structs by
+one of their fields, and so on. Two values are treated as equal when neither
+is less than the other.
+*/
+package main
+
+type node[T any] struct {
+	value       T
+	left, right *node[T]
+}
+
+// BST is a binary search tree ordered by less. Create it with NewBST.
+type BST[T any] struct {
+	root *node[T]
+	less func(a, b T) bool
+}
+
+// NewBST returns an empty tree that orders its values with less.
+func NewBST[T any](less func(a, b T) bool) *BST[T] {
+	return &BST[T]{less: less}
+}
+
+// Insert adds v to the tree. A value equal to one already stored is ignored,
+// and Insert reports whether v was added.
+func (t *BST[T]) Insert(v T) bool {
+	// link points to the pointer that will hold the new node, starting from
+	// the root, so an empty tree needs no special case.
+	link := &t.root
+	for *link != nil {
+		n := *link
+		switch {
+		case t.less(v, n.value):
+			link = &n.left
+		case t.less(n.value, v):
+			link = &n.right
+		default:
+			return false // duplicate
+		}
+	}
+	*link = &node[T]{value: v}
+	return true
+}
+
+// Contains reports whether a value equal to v is in the tree.
+func (t *BST[T]) Contains(v T) bool {
+	n := t.root
+	for n != nil {
+		switch {
+		case t.less(v, n.value):
+			n = n.left
+		case t.less(n.value, v):
+			n = n.right
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// InOrder returns all the values in sorted order, or nil for an empty tree.
+func (t *BST[T]) InOrder() []T {
+	var out []T
+	var walk func(n *node[T])
+	walk = func(n *node[T]) {
+		if n == nil {
+			return
+		}
+		walk(n.left)
+		out = append(out, n.value)
+		walk(n.right)
+	}
+	walk(t.root)
+	return out
+}
+
+// Height returns the number of nodes on the longest path from the root down
+// to a leaf: 0 for an empty tree, 1 for a tree with only a root.
+func (t *BST[T]) Height() int {
+	return height(t.root)
+}
+
+func height[T any](n *node[T]) int {
+	if n == nil {
+		return 0
+	}
+	return 1 + max(height(n.left), height(n.right))
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestBSTInts(t *testing.T) {
+	tests := []struct {
+		name   string
+		insert []int
+		want   []int
+		height int
+	}{
+		{"empty", nil, nil, 0},
+		{"one", []int{7}, []int{7}, 1},
+		{"balanced", []int{50, 30, 70, 20, 40, 60, 80}, []int{20, 30, 40, 50, 60, 70, 80}, 3},
+		{"duplicates ignored", []int{3, 1, 3, 2, 1}, []int{1, 2, 3}, 3},
+		{"sorted insert is a line", []int{1, 2, 3, 4, 5}, []int{1, 2, 3, 4, 5}, 5},
+		{"negatives", []int{0, -5, 5, -10}, []int{-10, -5, 0, 5}, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree := NewBST(func(a, b int) bool { return a < b })
+			for _, v := range tt.insert {
+				tree.Insert(v)
+			}
+			if got := tree.InOrder(); !slices.Equal(got, tt.want) {
+				t.Errorf("InOrder = %v, want %v", got, tt.want)
+			}
+			if got := tree.Height(); got != tt.height {
+				t.Errorf("Height = %d, want %d", got, tt.height)
+			}
+			for _, v := range tt.want {
+				if !tree.Contains(v) {
+					t.Errorf("Contains(%d) = false", v)
+				}
+			}
+			if tree.Contains(1000) {
+				t.Error("Contains(1000) = true")
+			}
+		})
+	}
+}
+
+func TestBSTInsertReportsDuplicates(t *testing.T) {
+	tree := NewBST(func(a, b int) bool { return a < b })
+	if !tree.Insert(1) {
+		t.Error("first Insert(1) = false")
+	}
+	if tree.Insert(1) {
+		t.Error("second Insert(1) = true")
+	}
+}
+
+func TestBSTStrings(t *testing.T) {
+	byLength := func(a, b string) bool {
+		if len(a) != len(b) {
+			return len(a) < len(b)
+		}
+		return a < b
+	}
+	alphabetical := func(a, b string) bool { return a < b }
+	words := []string{"banana", "kiwi", "apple", "fig", "cherry", "date"}
+
+	tests := []struct {
+		name string
+		less func(a, b string) bool
+		want []string
+	}{
+		{"alphabetical", alphabetical, []string{"apple", "banana", "cherry", "date", "fig", "kiwi"}},
+		{"by length", byLength, []string{"fig", "date", "kiwi", "apple", "banana", "cherry"}},
+		{"reversed", func(a, b string) bool { return a > b }, []string{"kiwi", "fig", "date", "cherry", "banana", "apple"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree := NewBST(tt.less)
+			for _, w := range words {
+				tree.Insert(w)
+			}
+			if got := tree.InOrder(); !slices.Equal(got, tt.want) {
+				t.Errorf("InOrder = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// Two values are equal when neither is less, so "fig" and "kiwi" collide when
+// only the length matters.
+func TestBSTComparatorEquality(t *testing.T) {
+	tree := NewBST(func(a, b string) bool { return len(a) < len(b) })
+	tree.Insert("date")
+	if tree.Insert("kiwi") {
+		t.Error(`Insert("kiwi") = true, want it treated as a duplicate of "date"`)
+	}
+	if !tree.Contains("abcd") {
+		t.Error(`Contains("abcd") = false`)
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+func main() {
+	// Keep the demo quick.
+	BaseDelay = 10 * time.Millisecond
+
+	calls := 0
+	err := Do(context.Background(), 5, func() error {
+		calls++
+		if calls < 3 {
+			return fmt.Errorf("temporary failure %d", calls)
+		}
+		return nil
+	})
+	fmt.Printf("succeeded after %d calls: %v\n", calls, err)
+
+	calls = 0
+	err = Do(context.Background(), 5, func() error {
+		calls++
+		return Permanent(errors.New("wrong password"))
+	})
+	fmt.Printf("permanent error after %d call: %v\n", calls, err)
+
+	calls = 0
+	err = Do(context.Background(), 3, func() error {
+		calls++
+		return errors.New("still down")
+	})
+	fmt.Printf("gave up after %d calls: %v\n", calls, err)
+
+	// The context runs out long before 10 attempts are done.
+	ctx, cancel := context.WithTimeout(context.Background(), 25*time.Millisecond)
+	defer cancel()
+	err = Do(ctx, 10, func() error { return errors.New("still down") })
+	fmt.Println(err, "- deadline exceeded:", errors.Is(err, context.DeadlineExceeded))
+}
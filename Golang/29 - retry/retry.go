@@ -0,0 +1,78 @@
+/*
+Retrying a failing operation with exponential backoff: after every failure we
+wait twice as long as the time before (100ms, 200ms, 400ms, ...) to give the
+other side time to recover.
+
+If many clients fail at the same moment (say, the server restarted), they
+would all retry at exactly the same moments too. Jitter, a random part of the
+wait, spreads them out.
+*/
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
+
+// Backoff settings used by Do. They are variables so a program (or a test)
+// that cannot wait that long can make them shorter.
+var (
+	BaseDelay = 100 * time.Millisecond
+	MaxDelay  = 5 * time.Second
+)
+
+// PermanentError marks an error that retrying cannot fix, like a wrong
+// password. When fn returns one, Do gives up at once.
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string { return e.Err.Error() }
+
+// Unwrap lets errors.Is and errors.As see the error inside.
+func (e *PermanentError) Unwrap() error { return e.Err }
+
+// Permanent wraps err in a PermanentError.
+func Permanent(err error) error {
+	return &PermanentError{Err: err}
+}
+
+// Do calls fn until it succeeds, up to attempts times, sleeping with
+// exponential backoff and jitter between attempts. It returns nil on success,
+// the *PermanentError as soon as fn returns one, and otherwise the last error
+// of fn. fn is always called at least once. If ctx ends while waiting, Do
+// stops and returns an error wrapping both ctx.Err() and the last error of
+// fn.
+func Do(ctx context.Context, attempts int, fn func() error) error {
+	attempts = max(attempts, 1)
+	var err error
+	delay := BaseDelay
+	for attempt := 1; attempt <= attempts; attempt++ {
+		err = fn()
+		if err == nil {
+			return nil
+		}
+		var permanent *PermanentError
+		if errors.As(err, &permanent) {
+			return err
+		}
+		if attempt == attempts {
+			break
+		}
+
+		// Wait between half and all of delay.
+		wait := delay/2 + rand.N(delay/2+1)
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("retry stopped after %d attempts: %w (last error: %w)", attempt, ctx.Err(), err)
+		}
+		delay = min(delay*2, MaxDelay)
+	}
+	return err
+}
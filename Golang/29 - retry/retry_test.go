@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// setDelays changes BaseDelay and MaxDelay for the rest of the test and puts
+// the old values back when it ends.
+func setDelays(t *testing.T, base, maxDelay time.Duration) {
+	t.Helper()
+	oldBase, oldMax := BaseDelay, MaxDelay
+	t.Cleanup(func() { BaseDelay, MaxDelay = oldBase, oldMax })
+	BaseDelay, MaxDelay = base, maxDelay
+}
+
+func TestDo(t *testing.T) {
+	setDelays(t, time.Millisecond, 2*time.Millisecond)
+	errTemporary := errors.New("temporary")
+	errFatal := errors.New("fatal")
+
+	tests := []struct {
+		name      string
+		attempts  int
+		failures  int   // how many calls fail before fn succeeds
+		failWith  error // the error of the failing calls
+		wantCalls int
+		wantErr   error
+	}{
+		{"first call works", 3, 0, errTemporary, 1, nil},
+		{"works on the last attempt", 3, 2, errTemporary, 3, nil},
+		{"gives up", 3, 10, errTemporary, 3, errTemporary},
+		{"permanent stops at once", 5, 10, Permanent(errFatal), 1, errFatal},
+		{"zero attempts still calls once", 0, 10, errTemporary, 1, errTemporary},
+		{"negative attempts still calls once", -2, 0, errTemporary, 1, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			err := Do(context.Background(), tt.attempts, func() error {
+				calls++
+				if calls <= tt.failures {
+					return tt.failWith
+				}
+				return nil
+			})
+			if calls != tt.wantCalls {
+				t.Errorf("fn called %d times, want %d", calls, tt.wantCalls)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSetDelaysRestores(t *testing.T) {
+	base, maxDelay := BaseDelay, MaxDelay
+	t.Run("changed", func(t *testing.T) {
+		setDelays(t, time.Nanosecond, time.Nanosecond)
+	})
+	if BaseDelay != base || MaxDelay != maxDelay {
+		t.Errorf("delays = %v, %v after the subtest, want %v, %v", BaseDelay, MaxDelay, base, maxDelay)
+	}
+}
+
+func TestDoPermanentIsReturnedAsIs(t *testing.T) {
+	err := Do(context.Background(), 3, func() error { return Permanent(errors.New("no")) })
+	var permanent *PermanentError
+	if !errors.As(err, &permanent) {
+		t.Fatalf("err = %v, want a *PermanentError", err)
+	}
+}
+
+func TestDoContextCancelled(t *testing.T) {
+	setDelays(t, time.Hour, time.Hour)
+	errDown := errors.New("down")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	calls := 0
+	err := Do(ctx, 5, func() error {
+		calls++
+		return errDown
+	})
+	if time.Since(start) > time.Second {
+		t.Fatalf("Do waited %v despite the context", time.Since(start))
+	}
+	if calls != 1 {
+		t.Errorf("fn called %d times, want 1", calls)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) || !errors.Is(err, errDown) {
+		t.Errorf("err = %v, want both the context error and the last error", err)
+	}
+}
@@ -0,0 +1,86 @@
+/*
+Reading a CSV file of sales with encoding/csv and adding up the amount sold
+for each product.
+
+Splitting lines on commas by hand breaks as soon as a field contains a comma.
+In CSV such a field is written between double quotes, like "Pen, blue", and
+encoding/csv takes care of it.
+*/
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Aggregate reads records of the form date,product,amount from r and returns
+// the total amount of every product. A first line naming the columns, with
+// "amount" as third column, is skipped. Errors report the line they were
+// found on.
+func Aggregate(r io.Reader) (map[string]float64, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = 3 // every line must have exactly three fields
+	cr.TrimLeadingSpace = true
+
+	totals := make(map[string]float64)
+	first := true
+	for {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return totals, nil
+		}
+		if err != nil {
+			// err is a *csv.ParseError, which already includes the line.
+			return nil, fmt.Errorf("aggregate: %w", err)
+		}
+
+		if first {
+			first = false
+			if strings.EqualFold(rec[2], "amount") {
+				continue // header
+			}
+		}
+
+		amount, err := strconv.ParseFloat(rec[2], 64)
+		if err != nil {
+			line, _ := cr.FieldPos(2)
+			return nil, fmt.Errorf("aggregate: line %d: invalid amount %q: %w", line, rec[2], err)
+		}
+		totals[rec[1]] += amount
+	}
+}
+
+const sales = `date,product,amount
+2024-03-01,Notebook,3.50
+2024-03-01,"Pen, blue",1.20
+2024-03-02,Notebook,3.50
+2024-03-02,"Pen, blue",1.20
+2024-03-03,Backpack,25.00
+`
+
+func main() {
+	totals, err := Aggregate(strings.NewReader(sales))
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+
+	products := make([]string, 0, len(totals))
+	for p := range totals {
+		products = append(products, p)
+	}
+	sort.Strings(products)
+	for _, p := range products {
+		fmt.Printf("%-10s %6.2f\n", p, totals[p])
+	}
+
+	bad := sales + "2024-03-04,Notebook,three\n"
+	if _, err := Aggregate(strings.NewReader(bad)); err != nil {
+		fmt.Println("Error:", err)
+	}
+}
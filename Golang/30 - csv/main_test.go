@@ -0,0 +1,57 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestAggregate(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  map[string]float64
+	}{
+		{"sales", sales, map[string]float64{"Notebook": 7, "Pen, blue": 2.4, "Backpack": 25}},
+		{"empty", "", map[string]float64{}},
+		{"header only", "date,product,amount\n", map[string]float64{}},
+		{"no header", "2024-03-01,Pen,1.5\n2024-03-02,Pen,2\n", map[string]float64{"Pen": 3.5}},
+		{"quoted field with quotes", `2024-03-01,"The ""Big"" Box",4` + "\n", map[string]float64{`The "Big" Box`: 4}},
+		{"leading spaces", "2024-03-01, Pen, 1.5\n", map[string]float64{"Pen": 1.5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Aggregate(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Aggregate = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAggregateBadNumber(t *testing.T) {
+	_, err := Aggregate(strings.NewReader(sales + "2024-03-04,Notebook,three\n"))
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Fatalf("err = %v, want strconv.ErrSyntax", err)
+	}
+	if want := `line 7: invalid amount "three"`; !strings.Contains(err.Error(), want) {
+		t.Errorf("err = %q, want it to contain %q", err, want)
+	}
+}
+
+func TestAggregateWrongFieldCount(t *testing.T) {
+	_, err := Aggregate(strings.NewReader("2024-03-01,Pen,1.5\n2024-03-02,Pen\n"))
+	var parseErr *csv.ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("err = %v, want a *csv.ParseError", err)
+	}
+	if parseErr.Line != 2 || !errors.Is(err, csv.ErrFieldCount) {
+		t.Errorf("err = %v, want a field count error on line 2", err)
+	}
+}
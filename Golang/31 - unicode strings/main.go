@@ -0,0 +1,70 @@
+/*
+A Go string is a sequence of bytes, normally UTF-8 text. In UTF-8 a character
+(a rune, in Go terms) takes from 1 to 4 bytes: "a" is one byte, "é" is two,
+"😀" is four. len(s) counts bytes, not characters.
+
+Reversing the bytes of "é" swaps its two bytes, which is not valid UTF-8 any
+more and prints as garbage. Converting to []rune first works on whole
+characters instead.
+
+Even runes are not always what a reader sees as one character: "é" can also be
+written as "e" followed by a combining accent (two runes), and some emoji,
+like families or flags, are several runes glued together. Reversing those
+correctly needs the grapheme rules of Unicode, which are outside the standard
+library.
+*/
+package main
+
+import (
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Reverse returns s with its runes in reverse order.
+func Reverse(s string) string {
+	r := []rune(s)
+	for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+		r[i], r[j] = r[j], r[i]
+	}
+	return string(r)
+}
+
+// reverseBytes is the wrong way to do it, kept here to compare.
+func reverseBytes(s string) string {
+	b := []byte(s)
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+	return string(b)
+}
+
+// IsPalindrome reports whether s reads the same forwards and backwards,
+// looking only at its letters and ignoring upper and lower case.
+func IsPalindrome(s string) bool {
+	var letters []rune
+	for _, r := range s { // ranging over a string gives runes, not bytes
+		if unicode.IsLetter(r) {
+			letters = append(letters, unicode.ToLower(r))
+		}
+	}
+	for i, j := 0, len(letters)-1; i < j; i, j = i+1, j-1 {
+		if letters[i] != letters[j] {
+			return false
+		}
+	}
+	return true
+}
+
+func main() {
+	for _, s := range []string{"hello", "résumé", "Go 😀 🚀"} {
+		fmt.Printf("%q: %d bytes, %d runes\n", s, len(s), utf8.RuneCountInString(s))
+		fmt.Printf("  runes: %q\n", Reverse(s))
+		bad := reverseBytes(s)
+		fmt.Printf("  bytes: %q (valid UTF-8: %v)\n", bad, utf8.ValidString(bad))
+	}
+
+	for _, s := range []string{"A man, a plan, a canal: Panama", "Été", "Gopher"} {
+		fmt.Printf("%q palindrome: %v\n", s, IsPalindrome(s))
+	}
+}
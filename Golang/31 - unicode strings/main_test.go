@@ -0,0 +1,61 @@
+package main
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+func TestReverse(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"", ""},
+		{"hello", "olleh"},
+		{"résumé", "émusér"},
+		{"Go 😀 🚀", "🚀 😀 oG"},
+		{"日本語", "語本日"},
+	}
+	for _, tt := range tests {
+		got := Reverse(tt.in)
+		if got != tt.want {
+			t.Errorf("Reverse(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+		if !utf8.ValidString(got) {
+			t.Errorf("Reverse(%q) is not valid UTF-8", tt.in)
+		}
+		if Reverse(got) != tt.in {
+			t.Errorf("Reverse(Reverse(%q)) = %q", tt.in, Reverse(got))
+		}
+	}
+}
+
+// Reversing bytes breaks every character longer than one byte.
+func TestReverseBytesBreaksUTF8(t *testing.T) {
+	for _, s := range []string{"résumé", "😀"} {
+		if utf8.ValidString(reverseBytes(s)) {
+			t.Errorf("reverseBytes(%q) is still valid UTF-8", s)
+		}
+	}
+	if got := reverseBytes("hello"); got != "olleh" {
+		t.Errorf(`reverseBytes("hello") = %q`, got)
+	}
+}
+
+func TestIsPalindrome(t *testing.T) {
+	tests := []struct {
+		in   string
+		want bool
+	}{
+		{"", true},
+		{"A man, a plan, a canal: Panama", true},
+		{"Été", true},
+		{"Gopher", false},
+		{"résumé", false},
+		{"😀 racecar 🚀", true},
+	}
+	for _, tt := range tests {
+		if got := IsPalindrome(tt.in); got != tt.want {
+			t.Errorf("IsPalindrome(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+func main() {
+	dir, err := os.MkdirTemp("", "kvstore")
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "store.json")
+
+	store := NewStore()
+	store.Set("language", "Go")
+	store.Set("mascot", "gopher")
+	store.Set("temporary", "delete me")
+	store.Delete("temporary")
+
+	// Readers and writers can work at the same time.
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			store.Set(fmt.Sprintf("key%d", i), fmt.Sprint(i*i))
+		}()
+		go func() {
+			defer wg.Done()
+			store.Get("language")
+		}()
+	}
+	wg.Wait()
+
+	if err := store.SaveToFile(path); err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+
+	loaded := NewStore()
+	if err := loaded.LoadFromFile(path); err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	for _, key := range []string{"language", "mascot", "key7", "temporary"} {
+		v, ok := loaded.Get(key)
+		fmt.Printf("%s = %q (%v)\n", key, v, ok)
+	}
+
+	if err := loaded.LoadFromFile(filepath.Join(dir, "missing.json")); err != nil {
+		fmt.Println("Error:", err)
+	}
+}
@@ -0,0 +1,108 @@
+/*
+A key-value store kept in memory and saved to a JSON file.
+
+sync.RWMutex allows many readers at the same time but only one writer, and no
+reader while somebody writes. Get takes the read lock (RLock), so lookups from
+many goroutines do not wait for each other; Set and Delete take the full lock.
+
+Saving writes a temporary file next to the real one and then renames it over
+the old file. Rename replaces the file in a single step, so if the program
+crashes halfway through a save the old file is still there, complete, instead
+of a half-written one.
+*/
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Store is a goroutine-safe string to string map. Create it with NewStore.
+type Store struct {
+	mu   sync.RWMutex
+	data map[string]string
+}
+
+func NewStore() *Store {
+	return &Store{data: make(map[string]string)}
+}
+
+// Get returns the value of key and whether it exists.
+func (s *Store) Get(key string) (string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	v, ok := s.data[key]
+	return v, ok
+}
+
+// Set stores value under key, replacing any previous value.
+func (s *Store) Set(key, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data[key] = value
+}
+
+// Delete removes key. Deleting a missing key does nothing.
+func (s *Store) Delete(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.data, key)
+}
+
+// SaveToFile writes the whole store to path as JSON, atomically.
+func (s *Store) SaveToFile(path string) error {
+	s.mu.RLock()
+	data, err := json.MarshalIndent(s.data, "", "  ")
+	s.mu.RUnlock()
+	if err != nil {
+		return fmt.Errorf("save store: %w", err)
+	}
+
+	// The temporary file must be in the same directory: rename cannot move a
+	// file to another disk in one step.
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("save store: %w", err)
+	}
+	// If anything goes wrong, do not leave the temporary file behind. After a
+	// successful rename this fails quietly, because the file is gone.
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("save store: %w", err)
+	}
+	// Sync asks the operating system to really put the data on disk before
+	// the rename makes it the official copy.
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("save store: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("save store: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("save store: %w", err)
+	}
+	return nil
+}
+
+// LoadFromFile replaces the content of the store with the JSON file at path.
+func (s *Store) LoadFromFile(path string) error {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("load store: %w", err)
+	}
+	data := make(map[string]string)
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return fmt.Errorf("load store: %s: %w", path, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data = data
+	return nil
+}
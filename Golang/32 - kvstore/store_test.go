@@ -0,0 +1,125 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestStoreSetGetDelete(t *testing.T) {
+	s := NewStore()
+	if _, ok := s.Get("missing"); ok {
+		t.Error(`Get("missing") found a value`)
+	}
+	s.Set("a", "1")
+	s.Set("a", "2")
+	if v, ok := s.Get("a"); !ok || v != "2" {
+		t.Errorf(`Get("a") = %q, %v, want "2", true`, v, ok)
+	}
+	s.Delete("a")
+	s.Delete("never set")
+	if _, ok := s.Get("a"); ok {
+		t.Error(`Get("a") found a value after Delete`)
+	}
+}
+
+func TestStorePersistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "store.json")
+	want := map[string]string{"language": "Go", "mascot": "gopher", "empty": "", "quote": `say "hi"`}
+
+	s := NewStore()
+	for k, v := range want {
+		s.Set(k, v)
+	}
+	if err := s.SaveToFile(path); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := NewStore()
+	loaded.Set("stale", "must disappear")
+	if err := loaded.LoadFromFile(path); err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range want {
+		if got, ok := loaded.Get(k); !ok || got != v {
+			t.Errorf("Get(%q) = %q, %v, want %q", k, got, ok, v)
+		}
+	}
+	if _, ok := loaded.Get("stale"); ok {
+		t.Error("LoadFromFile kept a key that was not in the file")
+	}
+
+	// Only the store file is left, no temporary files.
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("directory has %d entries, want only store.json", len(entries))
+	}
+}
+
+func TestStoreLoadErrors(t *testing.T) {
+	dir := t.TempDir()
+	s := NewStore()
+	if err := s.LoadFromFile(filepath.Join(dir, "missing.json")); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("missing file: err = %v, want fs.ErrNotExist", err)
+	}
+
+	bad := filepath.Join(dir, "bad.json")
+	if err := os.WriteFile(bad, []byte(`{"a": 1}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	s.Set("kept", "yes")
+	if err := s.LoadFromFile(bad); err == nil {
+		t.Error("bad JSON: want an error")
+	}
+	if _, ok := s.Get("kept"); !ok {
+		t.Error("a failed load changed the store")
+	}
+}
+
+// Run with go test -race *.go to check the locking.
+func TestStoreConcurrent(t *testing.T) {
+	s := NewStore()
+	path := filepath.Join(t.TempDir(), "store.json")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			s.Set(fmt.Sprint("key", i), fmt.Sprint(i))
+		}()
+		go func() {
+			defer wg.Done()
+			s.Get("key0")
+			s.Delete(fmt.Sprint("key", i-1))
+		}()
+		go func() {
+			defer wg.Done()
+			if i%10 == 0 {
+				if err := s.SaveToFile(path); err != nil {
+					t.Error(err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	s.Set("final", "value")
+	if err := s.SaveToFile(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded := NewStore()
+	if err := loaded.LoadFromFile(path); err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := loaded.Get("final"); v != "value" {
+		t.Errorf(`Get("final") = %q after reload`, v)
+	}
+}
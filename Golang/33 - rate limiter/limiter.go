@@ -0,0 +1,81 @@
+/*
+A rate limiter lets an action happen at most N times per second, for example
+to stay under the request limit of an API.
+
+This one is a token bucket made of channels: a time.Ticker drops a token into
+a buffered channel at the chosen rate, and Wait takes one token out, blocking
+while the channel is empty. The channel has room for a single token, so
+tokens do not pile up while nobody is waiting and there are no bursts.
+*/
+package main
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrStopped is returned by Wait once the limiter has been stopped.
+var ErrStopped = errors.New("rate limiter stopped")
+
+// Limiter hands out tokens at a fixed rate. Create it with NewLimiter and
+// call Stop when done with it.
+type Limiter struct {
+	tokens   chan struct{}
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewLimiter returns a limiter allowing ratePerSec calls of Wait per second.
+// The first token is available straight away. ratePerSec must be positive.
+func NewLimiter(ratePerSec int) *Limiter {
+	l := &Limiter{
+		tokens: make(chan struct{}, 1),
+		stop:   make(chan struct{}),
+	}
+	l.tokens <- struct{}{}
+
+	ticker := time.NewTicker(time.Second / time.Duration(ratePerSec))
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				select {
+				case l.tokens <- struct{}{}:
+				default:
+					// The bucket is full, this token is thrown away.
+				}
+			case <-l.stop:
+				return
+			}
+		}
+	}()
+	return l
+}
+
+// Wait blocks until a token is available, ctx is done (returning ctx.Err())
+// or the limiter is stopped (returning ErrStopped).
+func (l *Limiter) Wait(ctx context.Context) error {
+	select {
+	case <-l.stop:
+		return ErrStopped
+	default:
+	}
+
+	select {
+	case <-l.tokens:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-l.stop:
+		return ErrStopped
+	}
+}
+
+// Stop ends the goroutine refilling the tokens and stops its ticker.
+// Calling Stop more than once is fine.
+func (l *Limiter) Stop() {
+	l.stopOnce.Do(func() { close(l.stop) })
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLimiterRate(t *testing.T) {
+	tests := []struct {
+		rate, calls int
+	}{
+		{100, 1},
+		{100, 6},
+		{200, 11},
+	}
+	for _, tt := range tests {
+		l := NewLimiter(tt.rate)
+		start := time.Now()
+		for i := 0; i < tt.calls; i++ {
+			if err := l.Wait(context.Background()); err != nil {
+				t.Fatalf("rate %d: Wait: %v", tt.rate, err)
+			}
+		}
+		elapsed := time.Since(start)
+		l.Stop()
+
+		// The first token is free, every other one takes 1/rate seconds.
+		min := time.Duration(tt.calls-1) * time.Second / time.Duration(tt.rate)
+		if elapsed < min*9/10 {
+			t.Errorf("rate %d: %d calls took %v, want at least %v", tt.rate, tt.calls, elapsed, min)
+		}
+	}
+}
+
+// Idle time does not save up tokens for a burst: after the one stored token,
+// the second call waits for the next tick (up to 50ms) and the third for a
+// whole interval.
+func TestLimiterNoBurst(t *testing.T) {
+	l := NewLimiter(20) // a token every 50ms
+	defer l.Stop()
+	time.Sleep(200 * time.Millisecond)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		l.Wait(context.Background())
+	}
+	if elapsed := time.Since(start); elapsed < 45*time.Millisecond {
+		t.Errorf("3 calls after a pause took %v, want at least 50ms", elapsed)
+	}
+}
+
+func TestLimiterWaitErrors(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name    string
+		ctx     func() (context.Context, context.CancelFunc)
+		stop    bool
+		wantErr error
+	}{
+		{"timeout", func() (context.Context, context.CancelFunc) {
+			return context.WithTimeout(context.Background(), 5*time.Millisecond)
+		}, false, context.DeadlineExceeded},
+		{"cancelled", func() (context.Context, context.CancelFunc) {
+			return cancelled, func() {}
+		}, false, context.Canceled},
+		{"stopped", func() (context.Context, context.CancelFunc) {
+			return context.Background(), func() {}
+		}, true, ErrStopped},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewLimiter(1)
+			defer l.Stop()
+			l.Wait(context.Background()) // use up the free first token
+			if tt.stop {
+				l.Stop()
+			}
+			ctx, cancel := tt.ctx()
+			defer cancel()
+			if err := l.Wait(ctx); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Wait = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+func main() {
+	limiter := NewLimiter(10)
+	defer limiter.Stop()
+
+	// The first call is free, the next four wait 100ms each.
+	start := time.Now()
+	for i := 1; i <= 5; i++ {
+		if err := limiter.Wait(context.Background()); err != nil {
+			fmt.Println("Error:", err)
+			return
+		}
+		fmt.Printf("call %d at %v\n", i, time.Since(start).Round(10*time.Millisecond))
+	}
+
+	// A context that ends before the next token arrives.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	fmt.Println("short wait:", limiter.Wait(ctx))
+
+	limiter.Stop()
+	fmt.Println("after stop:", limiter.Wait(context.Background()))
+}
@@ -0,0 +1,108 @@
+/*
+Go checks that a type satisfies an interface only where a value of that type
+is used as the interface. If that never happens in the package, a mistake in
+a method signature goes unnoticed until some other code tries it.
+
+The line
+
+	var _ fmt.Stringer = (*Temperature)(nil)
+
+forces the check at compile time. It converts nil to a *Temperature, assigns
+it to a fmt.Stringer variable and throws it away (_), so it costs nothing
+when the program runs.
+
+If Email.Validate returned bool instead of error, the assertion for Email
+below would fail to compile with:
+
+	cannot use (*Email)(nil) (value of type *Email) as Validator value in
+	variable declaration: *Email does not implement Validator (wrong type
+	for method Validate)
+			have Validate() bool
+			want Validate() error
+*/
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// Validator is implemented by values that can check themselves.
+type Validator interface {
+	Validate() error
+}
+
+// Compile-time checks: the build fails if a type loses one of its methods.
+var (
+	_ fmt.Stringer = (*Temperature)(nil)
+	_ io.Writer    = (*CountingWriter)(nil)
+	_ Validator    = (*Email)(nil)
+	_ Validator    = (*Username)(nil)
+)
+
+type Temperature float64
+
+func (t *Temperature) String() string {
+	return fmt.Sprintf("%.1f°C", float64(*t))
+}
+
+// CountingWriter counts the bytes written to it and throws them away.
+type CountingWriter struct {
+	N int
+}
+
+func (w *CountingWriter) Write(p []byte) (int, error) {
+	w.N += len(p)
+	return len(p), nil
+}
+
+type Email string
+
+func (e *Email) Validate() error {
+	if !strings.Contains(string(*e), "@") {
+		return fmt.Errorf("email %q has no @", string(*e))
+	}
+	return nil
+}
+
+type Username string
+
+func (u *Username) Validate() error {
+	if n := utf8.RuneCountInString(string(*u)); n < 3 {
+		return fmt.Errorf("username %q is too short: %d characters, need 3", string(*u), n)
+	}
+	return nil
+}
+
+// RunAll validates every value and returns the errors of the ones that
+// failed. It returns nil when they all pass.
+func RunAll(vs []Validator) []error {
+	var errs []error
+	for _, v := range vs {
+		if err := v.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+func main() {
+	t := Temperature(21.5)
+	fmt.Println(&t)
+
+	var w CountingWriter
+	fmt.Fprintf(&w, "hello, %s", "world")
+	fmt.Println("bytes written:", w.N)
+
+	good, bad := Email("ada@example.com"), Email("ada.example.com")
+	short, long := Username("al"), Username("gopher")
+	errs := RunAll([]Validator{&good, &bad, &short, &long})
+	for _, err := range errs {
+		fmt.Println("invalid:", err)
+	}
+	// errors.Join turns the list into a single error, one per line.
+	fmt.Println(errors.Join(errs...))
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+func TestRunAll(t *testing.T) {
+	tests := []struct {
+		name string
+		vs   []Validator
+		want []string
+	}{
+		{"none", nil, nil},
+		{"all valid", []Validator{ptr(Email("ada@example.com")), ptr(Username("gopher"))}, nil},
+		{
+			"some invalid, in order",
+			[]Validator{ptr(Email("ada@example.com")), ptr(Email("ada.example.com")), ptr(Username("al")), ptr(Username("gopher"))},
+			[]string{
+				`email "ada.example.com" has no @`,
+				`username "al" is too short: 2 characters, need 3`,
+			},
+		},
+		{"runes, not bytes", []Validator{ptr(Username("éé")), ptr(Username("été"))}, []string{
+			`username "éé" is too short: 2 characters, need 3`,
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := RunAll(tt.vs)
+			if tt.want == nil && errs != nil {
+				t.Fatalf("RunAll = %v, want nil", errs)
+			}
+			if len(errs) != len(tt.want) {
+				t.Fatalf("RunAll returned %d errors, want %d: %v", len(errs), len(tt.want), errs)
+			}
+			for i, err := range errs {
+				if err.Error() != tt.want[i] {
+					t.Errorf("errs[%d] = %q, want %q", i, err, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRunAllJoined(t *testing.T) {
+	errs := RunAll([]Validator{ptr(Email("x")), ptr(Username("y"))})
+	joined := errors.Join(errs...)
+	want := "email \"x\" has no @\nusername \"y\" is too short: 1 characters, need 3"
+	if joined == nil || joined.Error() != want {
+		t.Errorf("joined = %q, want %q", joined, want)
+	}
+	for _, err := range errs {
+		if !errors.Is(joined, err) {
+			t.Errorf("joined error does not wrap %v", err)
+		}
+	}
+}
+
+func TestStringerAndWriter(t *testing.T) {
+	temp := Temperature(-3.25)
+	if got := fmt.Sprint(&temp); got != "-3.2°C" {
+		t.Errorf("Temperature = %q, want %q", got, "-3.2°C")
+	}
+	var w CountingWriter
+	fmt.Fprintf(&w, "hello, %s", "wörld")
+	if w.N != 13 {
+		t.Errorf("CountingWriter.N = %d, want 13", w.N)
+	}
+}
@@ -0,0 +1,88 @@
+/*
+A small grep: print the lines of a file that match a regular expression.
+
+Usage:
+
+	go run main.go [-v] pattern [file]
+
+-v prints the lines that do NOT match. Without a file it reads the standard
+input, so it also works at the end of a pipe:
+
+	cat main.go | go run main.go -v '^$'
+*/
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+)
+
+// MaxLineSize is the longest line Grep accepts. bufio.Scanner stops at 64 KB
+// by default, which a minified file or a log line can easily exceed.
+const MaxLineSize = 16 * 1024 * 1024
+
+// Grep copies to w the lines of r matching pattern, or the lines not matching
+// it when invert is true, and returns how many lines it wrote. An invalid
+// pattern is reported before anything is read from r.
+func Grep(r io.Reader, w io.Writer, pattern string, invert bool) (matched int, err error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return 0, fmt.Errorf("grep: invalid pattern: %w", err)
+	}
+
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), MaxLineSize)
+	bw := bufio.NewWriter(w)
+	for sc.Scan() {
+		line := sc.Bytes()
+		if re.Match(line) == invert {
+			continue
+		}
+		matched++
+		bw.Write(line)
+		if err := bw.WriteByte('\n'); err != nil {
+			return matched, fmt.Errorf("grep: %w", err)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return matched, fmt.Errorf("grep: %w", err)
+	}
+	if err := bw.Flush(); err != nil {
+		return matched, fmt.Errorf("grep: %w", err)
+	}
+	return matched, nil
+}
+
+func main() {
+	invert := flag.Bool("v", false, "print the lines that do not match")
+	flag.Parse()
+	if flag.NArg() < 1 || flag.NArg() > 2 {
+		fmt.Fprintln(os.Stderr, "usage: grep [-v] pattern [file]")
+		os.Exit(2)
+	}
+
+	var input io.Reader = os.Stdin
+	if flag.NArg() == 2 {
+		f, err := os.Open(flag.Arg(1))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "grep:", err)
+			os.Exit(2)
+		}
+		defer f.Close()
+		input = f
+	}
+
+	matched, err := Grep(input, os.Stdout, flag.Arg(0), *invert)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	// Like the real grep: exit code 1 means "nothing found".
+	if matched == 0 {
+		os.Exit(1)
+	}
+}
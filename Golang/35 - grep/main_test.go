@@ -0,0 +1,71 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"regexp/syntax"
+	"strings"
+	"testing"
+)
+
+const sample = `package main
+
+import "fmt"
+
+func main() {
+	fmt.Println("hello")
+}
+`
+
+func TestGrep(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		invert  bool
+		want    string
+	}{
+		{"match", "fmt", false, "import \"fmt\"\n\tfmt.Println(\"hello\")\n"},
+		{"invert", "fmt", true, "package main\n\n\nfunc main() {\n}\n"},
+		{"blank lines", "^$", false, "\n\n"},
+		{"drop blank lines", "^$", true, "package main\nimport \"fmt\"\nfunc main() {\n\tfmt.Println(\"hello\")\n}\n"},
+		{"no match", "goroutine", false, ""},
+		{"invert everything", ".*", true, ""},
+		{"anchored", "^func", false, "func main() {\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			matched, err := Grep(strings.NewReader(sample), &out, tt.pattern, tt.invert)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if out.String() != tt.want {
+				t.Errorf("output = %q, want %q", out.String(), tt.want)
+			}
+			if want := strings.Count(tt.want, "\n"); matched != want {
+				t.Errorf("matched = %d, want %d", matched, want)
+			}
+		})
+	}
+}
+
+func TestGrepInvalidPattern(t *testing.T) {
+	var out strings.Builder
+	_, err := Grep(strings.NewReader(sample), &out, "a(b", false)
+	var syntaxErr *syntax.Error
+	if !errors.As(err, &syntaxErr) {
+		t.Errorf("err = %v, want a *syntax.Error", err)
+	}
+}
+
+func TestGrepLongLine(t *testing.T) {
+	long := strings.Repeat("x", bufio.MaxScanTokenSize+1)
+	var out strings.Builder
+	matched, err := Grep(strings.NewReader("short\n"+long+"\n"), &out, "^x+$", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if matched != 1 || out.String() != long+"\n" {
+		t.Errorf("matched = %d, output of %d bytes", matched, out.Len())
+	}
+}
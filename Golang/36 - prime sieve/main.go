@@ -0,0 +1,112 @@
+/*
+Two ways to find all the prime numbers up to n.
+
+PrimesUpTo is the Sieve of Eratosthenes: write down every number, then cross
+out the multiples of 2, of 3, of 5 and so on. What is never crossed out is
+prime. It is fast and only needs a slice of booleans.
+
+ConcurrentPrimes is the classic Go prime sieve: a chain of goroutines
+connected by channels. The first goroutine sends 2, 3, 4, 5, ... and every
+time a new prime p comes out of the end of the chain, a filter goroutine that
+drops the multiples of p is added to it. It shows off channels nicely but is
+much slower, since every number travels through many goroutines. main times
+both; for precise numbers run the benchmarks in main_test.go:
+
+	go test -bench . *.go
+*/
+package main
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// PrimesUpTo returns the primes from 2 to n, in order. For n < 2 it returns
+// an empty slice.
+func PrimesUpTo(n int) []int {
+	primes := []int{}
+	if n < 2 {
+		return primes
+	}
+	composite := make([]bool, n+1)
+	for i := 2; i <= n; i++ {
+		if composite[i] {
+			continue
+		}
+		primes = append(primes, i)
+		// Smaller multiples of i were crossed out by smaller primes already.
+		for j := i * i; j <= n; j += i {
+			composite[j] = true
+		}
+	}
+	return primes
+}
+
+// generate sends 2, 3, ..., n on the returned channel and then closes it.
+func generate(n int) <-chan int {
+	out := make(chan int)
+	go func() {
+		defer close(out)
+		for i := 2; i <= n; i++ {
+			out <- i
+		}
+	}()
+	return out
+}
+
+// filter copies the values of in that are not multiples of prime, closing its
+// output when in is closed.
+func filter(in <-chan int, prime int) <-chan int {
+	out := make(chan int)
+	go func() {
+		defer close(out)
+		for i := range in {
+			if i%prime != 0 {
+				out <- i
+			}
+		}
+	}()
+	return out
+}
+
+// ConcurrentPrimes returns the same result as PrimesUpTo, computed by a chain
+// of filter goroutines. Every stage closes its channel when its input runs
+// out, so all the goroutines end together with the function.
+func ConcurrentPrimes(n int) []int {
+	primes := []int{}
+	if n < 2 {
+		return primes
+	}
+	ch := generate(n)
+	for {
+		// The first number to get through all the filters is always prime.
+		prime, ok := <-ch
+		if !ok {
+			return primes
+		}
+		primes = append(primes, prime)
+		ch = filter(ch, prime)
+	}
+}
+
+// timePrimes returns the average time of one call of primes(n).
+func timePrimes(primes func(int) []int, n int) time.Duration {
+	const runs = 5
+	start := time.Now()
+	for i := 0; i < runs; i++ {
+		primes(n)
+	}
+	return time.Since(start) / runs
+}
+
+func main() {
+	for _, n := range []int{0, 1, 2, 30, 100} {
+		sieve, concurrent := PrimesUpTo(n), ConcurrentPrimes(n)
+		fmt.Printf("n=%d: %v (same: %v)\n", n, sieve, slices.Equal(sieve, concurrent))
+	}
+
+	fmt.Println("Primes up to 10000:")
+	fmt.Printf("  sieve:      %12v\n", timePrimes(PrimesUpTo, 10000))
+	fmt.Printf("  goroutines: %12v\n", timePrimes(ConcurrentPrimes, 10000))
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPrimes(t *testing.T) {
+	tests := []struct {
+		n    int
+		want []int
+	}{
+		{-5, []int{}},
+		{0, []int{}},
+		{1, []int{}},
+		{2, []int{2}},
+		{3, []int{2, 3}},
+		{10, []int{2, 3, 5, 7}},
+		{30, []int{2, 3, 5, 7, 11, 13, 17, 19, 23, 29}},
+	}
+	for _, tt := range tests {
+		if got := PrimesUpTo(tt.n); !slices.Equal(got, tt.want) {
+			t.Errorf("PrimesUpTo(%d) = %v, want %v", tt.n, got, tt.want)
+		}
+		if got := ConcurrentPrimes(tt.n); !slices.Equal(got, tt.want) {
+			t.Errorf("ConcurrentPrimes(%d) = %v, want %v", tt.n, got, tt.want)
+		}
+	}
+	if n := len(PrimesUpTo(10000)); n != 1229 {
+		t.Errorf("%d primes up to 10000, want 1229", n)
+	}
+}
+
+func BenchmarkPrimesUpTo(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		PrimesUpTo(10000)
+	}
+}
+
+func BenchmarkConcurrentPrimes(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		ConcurrentPrimes(10000)
+	}
+}
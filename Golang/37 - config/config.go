@@ -0,0 +1,117 @@
+/*
+Loading settings from a JSON file, then letting environment variables
+override some of them, which is handy in containers where editing a file is
+harder than setting a variable.
+
+Each field says which variable overrides it with an `env:"..."` struct tag.
+Instead of writing one if per field, applyEnv uses the reflect package to
+walk through the struct, read the tags and set the fields, whatever their
+names. It also walks into embedded and nested structs like Database.
+*/
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+)
+
+// Database is embedded in Config: its fields are promoted, so in JSON they
+// sit at the top level too ("db_host", not "Database": {...}).
+type Database struct {
+	DBHost string `json:"db_host" env:"APP_DB_HOST"`
+	DBPort int    `json:"db_port" env:"APP_DB_PORT"`
+}
+
+type Config struct {
+	Name  string  `json:"name" env:"APP_NAME"`
+	Port  int     `json:"port" env:"APP_PORT"`
+	Debug bool    `json:"debug" env:"APP_DEBUG"`
+	Ratio float64 `json:"ratio" env:"APP_RATIO"`
+	Database
+}
+
+// LoadConfig reads the JSON file at path and then applies the environment
+// variables named by the env tags. A variable that is set but cannot be
+// converted to the field's type is an error naming the field.
+func LoadConfig(path string) (Config, error) {
+	var c Config
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return c, fmt.Errorf("load config: %w", err)
+	}
+	if err := json.Unmarshal(data, &c); err != nil {
+		return c, fmt.Errorf("load config: %s: %w", path, err)
+	}
+	// applyEnv needs the struct itself, not a copy, to be able to change it:
+	// hence the pointer and Elem().
+	if err := applyEnv(reflect.ValueOf(&c).Elem(), ""); err != nil {
+		return c, fmt.Errorf("load config: %w", err)
+	}
+	return c, nil
+}
+
+// applyEnv sets the fields of the struct v from the environment. prefix is
+// the path of v inside the top level struct, used in error messages.
+func applyEnv(v reflect.Value, prefix string) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		value := v.Field(i)
+		if !field.IsExported() {
+			continue // reflect cannot set unexported fields
+		}
+		name := prefix + field.Name
+
+		if field.Type.Kind() == reflect.Struct {
+			if err := applyEnv(value, name+"."); err != nil {
+				return err
+			}
+			continue
+		}
+
+		key := field.Tag.Get("env")
+		if key == "" {
+			continue
+		}
+		raw, ok := os.LookupEnv(key)
+		if !ok {
+			continue
+		}
+		if err := setFromString(value, raw); err != nil {
+			return fmt.Errorf("field %s from %s=%q: %w", name, key, raw, err)
+		}
+	}
+	return nil
+}
+
+// setFromString converts raw to the type of v and stores it.
+func setFromString(v reflect.Value, raw string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
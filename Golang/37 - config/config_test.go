@@ -0,0 +1,92 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+const configJSON = `{"name": "shop", "port": 8080, "ratio": 0.5, "db_host": "localhost", "db_port": 5432}`
+
+// writeConfig writes data to a config file in a temporary directory and
+// returns its path.
+func writeConfig(t *testing.T, data string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadConfigEnvOverrides(t *testing.T) {
+	fromFile := Config{Name: "shop", Port: 8080, Ratio: 0.5, Database: Database{DBHost: "localhost", DBPort: 5432}}
+
+	tests := []struct {
+		name string
+		env  map[string]string
+		want Config
+	}{
+		{"file only", nil, fromFile},
+		{"port", map[string]string{"APP_PORT": "9090"}, Config{Name: "shop", Port: 9090, Ratio: 0.5, Database: fromFile.Database}},
+		{"every kind", map[string]string{
+			"APP_NAME":    "store",
+			"APP_DEBUG":   "true",
+			"APP_RATIO":   "0.25",
+			"APP_DB_HOST": "db.internal",
+			"APP_DB_PORT": "6543",
+		}, Config{Name: "store", Port: 8080, Debug: true, Ratio: 0.25, Database: Database{DBHost: "db.internal", DBPort: 6543}}},
+		{"empty string still overrides", map[string]string{"APP_NAME": ""}, Config{Port: 8080, Ratio: 0.5, Database: fromFile.Database}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			got, err := LoadConfig(writeConfig(t, configJSON))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("LoadConfig = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadConfigBadEnv(t *testing.T) {
+	tests := []struct {
+		key, value string
+		message    string
+	}{
+		{"APP_DB_PORT", "five thousand", `field Database.DBPort from APP_DB_PORT="five thousand"`},
+		{"APP_PORT", "99999999999999999999", "field Port"},
+		{"APP_DEBUG", "maybe", "field Debug"},
+		{"APP_RATIO", "half", "field Ratio"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			t.Setenv(tt.key, tt.value)
+			_, err := LoadConfig(writeConfig(t, configJSON))
+			var numErr *strconv.NumError
+			if !errors.As(err, &numErr) {
+				t.Errorf("err = %v, want a *strconv.NumError", err)
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.message) {
+				t.Errorf("err = %v, want it to contain %q", err, tt.message)
+			}
+		})
+	}
+}
+
+func TestLoadConfigFileErrors(t *testing.T) {
+	if _, err := LoadConfig(filepath.Join(t.TempDir(), "missing.json")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("missing file: err = %v, want os.ErrNotExist", err)
+	}
+	if _, err := LoadConfig(writeConfig(t, `{"port": "eighty"}`)); err == nil {
+		t.Error("wrong JSON type: want an error")
+	}
+}
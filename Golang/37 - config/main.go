@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	dir, err := os.MkdirTemp("", "config")
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "config.json")
+	data := `{"name": "shop", "port": 8080, "ratio": 0.5, "db_host": "localhost", "db_port": 5432}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+
+	c, err := LoadConfig(path)
+	fmt.Printf("from the file:  %+v %v\n", c, err)
+
+	// Normally these would be set outside the program, for example
+	// APP_PORT=9090 go run main.go config.go
+	os.Setenv("APP_PORT", "9090")
+	os.Setenv("APP_DEBUG", "true")
+	os.Setenv("APP_DB_HOST", "db.internal")
+	c, err = LoadConfig(path)
+	fmt.Printf("with overrides: %+v %v\n", c, err)
+
+	os.Setenv("APP_DB_PORT", "five thousand")
+	_, err = LoadConfig(path)
+	fmt.Println("Error:", err)
+}
@@ -0,0 +1,28 @@
+package main
+
+import "fmt"
+
+func main() {
+	a := NewSet(1, 2, 3, 4)
+	b := NewSet(3, 4, 5, 6)
+	c := NewSet(10, 11)
+
+	fmt.Println("a:", Sorted(a), "b:", Sorted(b))
+	fmt.Println("a ∪ b:", Sorted(a.Union(b)))
+	fmt.Println("a ∩ b:", Sorted(a.Intersection(b)))
+	fmt.Println("a - b:", Sorted(a.Difference(b)))
+	fmt.Println("b - a:", Sorted(b.Difference(a)))
+
+	// Disjoint sets have nothing in common.
+	fmt.Println("a ∩ c:", Sorted(a.Intersection(c)), "a - c:", Sorted(a.Difference(c)))
+
+	// None of the operations above changed a or b.
+	fmt.Println("a and b again:", Sorted(a), Sorted(b))
+
+	// Any comparable type works, duplicates are dropped.
+	type point struct{ x, y int }
+	points := NewSet(point{1, 2}, point{1, 2}, point{3, 4})
+	fmt.Println("points:", points.Len(), points.Contains(point{3, 4}))
+	points.Remove(point{3, 4})
+	fmt.Println("after remove:", points.ToSlice())
+}
@@ -0,0 +1,98 @@
+/*
+Go has no built-in set type. The usual replacement is a map whose values are
+empty structs: map[T]struct{}. struct{} takes no memory, so only the keys
+cost anything, and looking up a key is as fast as in any map.
+
+Union, Intersection and Difference never change the sets they are called on,
+they always build and return a new one.
+*/
+package main
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Set is an unordered collection of distinct values. Create it with NewSet.
+type Set[T comparable] struct {
+	items map[T]struct{}
+}
+
+// NewSet returns a set holding the given values, duplicates counted once.
+func NewSet[T comparable](values ...T) *Set[T] {
+	s := &Set[T]{items: make(map[T]struct{}, len(values))}
+	for _, v := range values {
+		s.Add(v)
+	}
+	return s
+}
+
+func (s *Set[T]) Add(v T) { s.items[v] = struct{}{} }
+
+func (s *Set[T]) Remove(v T) { delete(s.items, v) }
+
+func (s *Set[T]) Contains(v T) bool {
+	_, ok := s.items[v]
+	return ok
+}
+
+func (s *Set[T]) Len() int { return len(s.items) }
+
+// Union returns the values that are in s, in other, or in both.
+func (s *Set[T]) Union(other *Set[T]) *Set[T] {
+	out := NewSet[T]()
+	for v := range s.items {
+		out.Add(v)
+	}
+	for v := range other.items {
+		out.Add(v)
+	}
+	return out
+}
+
+// Intersection returns the values that are in both s and other.
+func (s *Set[T]) Intersection(other *Set[T]) *Set[T] {
+	out := NewSet[T]()
+	// Loop over the smaller set, checking the bigger one is cheaper.
+	small, big := s, other
+	if small.Len() > big.Len() {
+		small, big = big, small
+	}
+	for v := range small.items {
+		if big.Contains(v) {
+			out.Add(v)
+		}
+	}
+	return out
+}
+
+// Difference returns the values of s that are not in other.
+func (s *Set[T]) Difference(other *Set[T]) *Set[T] {
+	out := NewSet[T]()
+	for v := range s.items {
+		if !other.Contains(v) {
+			out.Add(v)
+		}
+	}
+	return out
+}
+
+// ToSlice returns the values of s in no particular order: like every map,
+// the order can change from one call to the next. Use Sorted for a stable
+// order.
+func (s *Set[T]) ToSlice() []T {
+	out := make([]T, 0, len(s.items))
+	for v := range s.items {
+		out = append(out, v)
+	}
+	return out
+}
+
+// Sorted returns the values of s in increasing order. It is a function and
+// not a method because it only works for ordered types (numbers and
+// strings), while a Set can hold any comparable type.
+func Sorted[T cmp.Ordered](s *Set[T]) []T {
+	out := s.ToSlice()
+	slices.Sort(out)
+	return out
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSetOperations(t *testing.T) {
+	tests := []struct {
+		name         string
+		a, b         []int
+		union        []int
+		intersection []int
+		difference   []int // a minus b
+	}{
+		{"overlapping", []int{1, 2, 3, 4}, []int{3, 4, 5}, []int{1, 2, 3, 4, 5}, []int{3, 4}, []int{1, 2}},
+		{"disjoint", []int{1, 2}, []int{7, 8}, []int{1, 2, 7, 8}, []int{}, []int{1, 2}},
+		{"subset", []int{2, 3}, []int{1, 2, 3, 4}, []int{1, 2, 3, 4}, []int{2, 3}, []int{}},
+		{"equal", []int{5, 6}, []int{6, 5}, []int{5, 6}, []int{5, 6}, []int{}},
+		{"one empty", nil, []int{1}, []int{1}, []int{}, []int{}},
+		{"duplicates", []int{1, 1, 2}, []int{2, 2}, []int{1, 2}, []int{2}, []int{1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := NewSet(tt.a...), NewSet(tt.b...)
+			if got := Sorted(a.Union(b)); !slices.Equal(got, tt.union) {
+				t.Errorf("Union = %v, want %v", got, tt.union)
+			}
+			if got := Sorted(a.Intersection(b)); !slices.Equal(got, tt.intersection) {
+				t.Errorf("Intersection = %v, want %v", got, tt.intersection)
+			}
+			// Intersection does not depend on which set is the smaller one.
+			if got := Sorted(b.Intersection(a)); !slices.Equal(got, tt.intersection) {
+				t.Errorf("reversed Intersection = %v, want %v", got, tt.intersection)
+			}
+			if got := Sorted(a.Difference(b)); !slices.Equal(got, tt.difference) {
+				t.Errorf("Difference = %v, want %v", got, tt.difference)
+			}
+		})
+	}
+}
+
+func TestSetOperationsLeaveInputsAlone(t *testing.T) {
+	a, b := NewSet("x", "y"), NewSet("y", "z")
+	a.Union(b)
+	a.Intersection(b)
+	a.Difference(b)
+	if got := Sorted(a); !slices.Equal(got, []string{"x", "y"}) {
+		t.Errorf("a = %v after the operations", got)
+	}
+	if got := Sorted(b); !slices.Equal(got, []string{"y", "z"}) {
+		t.Errorf("b = %v after the operations", got)
+	}
+}
+
+func TestSetAddRemove(t *testing.T) {
+	s := NewSet[string]()
+	s.Add("go")
+	s.Add("go")
+	if s.Len() != 1 || !s.Contains("go") {
+		t.Fatalf("after two Add: Len = %d, Contains = %v", s.Len(), s.Contains("go"))
+	}
+	s.Remove("go")
+	s.Remove("missing")
+	if s.Len() != 0 || s.Contains("go") {
+		t.Errorf("after Remove: Len = %d, Contains = %v", s.Len(), s.Contains("go"))
+	}
+}
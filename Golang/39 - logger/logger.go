@@ -0,0 +1,94 @@
+/*
+A small leveled logger. Every message has a level, and messages below the
+logger's minimum level are dropped, so Debug messages can be left in the code
+and only switched on when needed.
+
+Each line is first built in memory and then written with a single Write call
+while holding a mutex, so lines logged from different goroutines at the
+same time never get mixed up.
+*/
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Level is how important a message is.
+type Level int
+
+const (
+	Debug Level = iota
+	Info
+	Warn
+	Error
+)
+
+func (l Level) String() string {
+	switch l {
+	case Debug:
+		return "DEBUG"
+	case Info:
+		return "INFO"
+	case Warn:
+		return "WARN"
+	case Error:
+		return "ERROR"
+	default:
+		return fmt.Sprintf("Level(%d)", int(l))
+	}
+}
+
+// TimeLayout is the format of the timestamp at the start of every line.
+const TimeLayout = "2006-01-02 15:04:05.000"
+
+// Logger writes leveled messages to an io.Writer. Create it with NewLogger.
+type Logger struct {
+	mu    sync.Mutex
+	w     io.Writer
+	level Level
+	// now gives the time printed on each line. It is time.Now, except in
+	// tests, which set a fixed clock to get the same output every run.
+	now func() time.Time
+}
+
+// NewLogger returns a logger writing to w the messages of level or above.
+func NewLogger(w io.Writer, level Level) *Logger {
+	return &Logger{w: w, level: level, now: time.Now}
+}
+
+// SetLevel changes the minimum level of the messages written.
+func (l *Logger) SetLevel(level Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.level = level
+}
+
+// Logf formats a message like fmt.Printf and writes it on its own line,
+// after the time and the level, unless level is below the minimum.
+func (l *Logger) Logf(level Level, format string, args ...any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if level < l.level {
+		return
+	}
+
+	var sb strings.Builder
+	sb.WriteString(l.now().Format(TimeLayout))
+	fmt.Fprintf(&sb, " %-5s ", level)
+	fmt.Fprintf(&sb, format, args...)
+	if !strings.HasSuffix(sb.String(), "\n") {
+		sb.WriteByte('\n')
+	}
+	// A logger has nowhere to report its own write errors, so they are
+	// ignored, like the standard log package does.
+	io.WriteString(l.w, sb.String())
+}
+
+func (l *Logger) Debugf(format string, args ...any) { l.Logf(Debug, format, args...) }
+func (l *Logger) Infof(format string, args ...any)  { l.Logf(Info, format, args...) }
+func (l *Logger) Warnf(format string, args ...any)  { l.Logf(Warn, format, args...) }
+func (l *Logger) Errorf(format string, args ...any) { l.Logf(Error, format, args...) }
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newTestLogger returns a logger writing to a buffer with a clock stopped at
+// 2024-03-10 09:30:00.125 UTC.
+func newTestLogger(level Level) (*Logger, *bytes.Buffer) {
+	var buf bytes.Buffer
+	l := NewLogger(&buf, level)
+	l.now = func() time.Time { return time.Date(2024, time.March, 10, 9, 30, 0, 125e6, time.UTC) }
+	return l, &buf
+}
+
+func logAll(l *Logger) {
+	l.Debugf("debug %d", 1)
+	l.Infof("info %s", "two")
+	l.Warnf("warn %.1f", 3.0)
+	l.Errorf("error %q", "four")
+}
+
+func TestLoggerLevels(t *testing.T) {
+	const (
+		debug = "2024-03-10 09:30:00.125 DEBUG debug 1\n"
+		info  = "2024-03-10 09:30:00.125 INFO  info two\n"
+		warn  = "2024-03-10 09:30:00.125 WARN  warn 3.0\n"
+		err   = "2024-03-10 09:30:00.125 ERROR error \"four\"\n"
+	)
+	tests := []struct {
+		level Level
+		want  string
+	}{
+		{Debug, debug + info + warn + err},
+		{Info, info + warn + err},
+		{Warn, warn + err},
+		{Error, err},
+		{Error + 1, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.level.String(), func(t *testing.T) {
+			l, buf := newTestLogger(tt.level)
+			logAll(l)
+			if buf.String() != tt.want {
+				t.Errorf("output:\n%s\nwant:\n%s", buf, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoggerSetLevel(t *testing.T) {
+	l, buf := newTestLogger(Error)
+	l.Infof("dropped")
+	l.SetLevel(Info)
+	l.Infof("kept")
+	if want := "2024-03-10 09:30:00.125 INFO  kept\n"; buf.String() != want {
+		t.Errorf("output = %q, want %q", buf, want)
+	}
+}
+
+func TestLoggerKeepsOneNewline(t *testing.T) {
+	l, buf := newTestLogger(Info)
+	l.Infof("already ends with a newline\n")
+	if strings.HasSuffix(buf.String(), "\n\n") {
+		t.Errorf("output = %q, want a single trailing newline", buf)
+	}
+}
+
+// Run with go test -race *.go: every line must come out whole.
+func TestLoggerConcurrent(t *testing.T) {
+	l, buf := newTestLogger(Info)
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			l.Infof("message from goroutine %d", i)
+		}()
+	}
+	wg.Wait()
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != 50 {
+		t.Fatalf("got %d lines, want 50", len(lines))
+	}
+	for _, line := range lines {
+		if !strings.HasPrefix(line, "2024-03-10 09:30:00.125 INFO  message from goroutine ") {
+			t.Errorf("mixed up line %q", line)
+		}
+	}
+}
+
+func TestLevelString(t *testing.T) {
+	if got := Level(7).String(); got != "Level(7)" {
+		t.Errorf("Level(7).String() = %q", got)
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sync"
+)
+
+func main() {
+	log := NewLogger(os.Stdout, Info)
+	log.Debugf("this is dropped, the level is %v", Info)
+	log.Infof("server started on port %d", 8080)
+	log.Warnf("disk %d%% full", 91)
+	log.Errorf("could not open %q", "data.db")
+
+	log.SetLevel(Debug)
+	log.Debugf("now debug messages show up too")
+
+	// Many goroutines writing at once still give whole lines.
+	var buf bytes.Buffer
+	shared := NewLogger(&buf, Info)
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			shared.Logf(Info, "message from goroutine %d", i)
+		}()
+	}
+	wg.Wait()
+	fmt.Println("lines written:", bytes.Count(buf.Bytes(), []byte("\n")))
+}
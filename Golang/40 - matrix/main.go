@@ -0,0 +1,42 @@
+package main
+
+import "fmt"
+
+func main() {
+	a, err := NewMatrix([][]float64{
+		{1, 2, 3},
+		{4, 5, 6},
+	})
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	b, err := NewMatrix([][]float64{
+		{7, 8},
+		{9, 10},
+		{11, 12},
+	})
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+
+	fmt.Print("A:\n", a, "A transposed:\n", a.Transpose())
+	fmt.Print("A transposed twice:\n", a.Transpose().Transpose())
+
+	product, err := a.Multiply(b)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	fmt.Print("A x B:\n", product)
+
+	// 2x3 times 2x3 does not fit.
+	if _, err := a.Multiply(a); err != nil {
+		fmt.Println("Error:", err)
+	}
+
+	if _, err := NewMatrix([][]float64{{1, 2}, {3}}); err != nil {
+		fmt.Println("Error:", err)
+	}
+}
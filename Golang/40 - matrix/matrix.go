@@ -0,0 +1,96 @@
+/*
+Matrices as slices of rows: m[i][j] is the number in row i, column j.
+
+A [][]float64 can be jagged, with rows of different lengths, which is not a
+matrix at all. NewMatrix checks the shape once, so the methods below can
+trust that every row has the same number of columns.
+*/
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Matrix is a rectangular grid of numbers. Build it with NewMatrix.
+type Matrix [][]float64
+
+// ErrDimension is returned when the sizes of matrices do not fit together.
+var ErrDimension = errors.New("matrix dimension mismatch")
+
+// NewMatrix returns a copy of rows as a Matrix. It fails when rows is empty,
+// when the rows are empty, or when they do not all have the same length.
+func NewMatrix(rows [][]float64) (Matrix, error) {
+	if len(rows) == 0 || len(rows[0]) == 0 {
+		return nil, errors.New("matrix must have at least one row and one column")
+	}
+	cols := len(rows[0])
+	m := make(Matrix, len(rows))
+	for i, row := range rows {
+		if len(row) != cols {
+			return nil, fmt.Errorf("row %d has %d columns, row 0 has %d", i, len(row), cols)
+		}
+		// Copy, so changing rows later does not change the matrix.
+		m[i] = append([]float64(nil), row...)
+	}
+	return m, nil
+}
+
+func (m Matrix) Rows() int { return len(m) }
+
+// Cols returns the number of columns, 0 for a Matrix with no rows, such as
+// a nil one that was not built with NewMatrix.
+func (m Matrix) Cols() int {
+	if len(m) == 0 {
+		return 0
+	}
+	return len(m[0])
+}
+
+// Transpose returns a new matrix whose rows are the columns of m.
+func (m Matrix) Transpose() Matrix {
+	t := make(Matrix, m.Cols())
+	for j := range t {
+		t[j] = make([]float64, m.Rows())
+		for i := range m {
+			t[j][i] = m[i][j]
+		}
+	}
+	return t
+}
+
+// Multiply returns the product m × b. The number of columns of m must equal
+// the number of rows of b, and the result has m's rows and b's columns.
+// An empty matrix never fits.
+func (m Matrix) Multiply(b Matrix) (Matrix, error) {
+	if m.Cols() != b.Rows() || m.Rows() == 0 || b.Cols() == 0 {
+		return nil, fmt.Errorf("%w: cannot multiply %dx%d by %dx%d",
+			ErrDimension, m.Rows(), m.Cols(), b.Rows(), b.Cols())
+	}
+	out := make(Matrix, m.Rows())
+	for i := range out {
+		out[i] = make([]float64, b.Cols())
+		for j := range out[i] {
+			// Row i of m times column j of b.
+			for k := 0; k < m.Cols(); k++ {
+				out[i][j] += m[i][k] * b[k][j]
+			}
+		}
+	}
+	return out, nil
+}
+
+func (m Matrix) String() string {
+	var sb strings.Builder
+	for _, row := range m {
+		for j, v := range row {
+			if j > 0 {
+				sb.WriteByte(' ')
+			}
+			fmt.Fprintf(&sb, "%6.1f", v)
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func mustMatrix(t *testing.T, rows [][]float64) Matrix {
+	t.Helper()
+	m, err := NewMatrix(rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestMultiply(t *testing.T) {
+	a := mustMatrix(t, [][]float64{{1, 2, 3}, {4, 5, 6}})
+	b := mustMatrix(t, [][]float64{{7, 8}, {9, 10}, {11, 12}})
+
+	got, err := a.Multiply(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Matrix{{58, 64}, {139, 154}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("A x B = %v, want %v", got, want)
+	}
+
+	// The other way round gives a 3x3 matrix.
+	got, err = b.Multiply(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Rows() != 3 || got.Cols() != 3 {
+		t.Errorf("B x A is %dx%d, want 3x3", got.Rows(), got.Cols())
+	}
+}
+
+func TestMultiplyIdentity(t *testing.T) {
+	a := mustMatrix(t, [][]float64{{1, 2}, {3, 4}})
+	id := mustMatrix(t, [][]float64{{1, 0}, {0, 1}})
+	got, err := a.Multiply(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, a) {
+		t.Errorf("A x I = %v, want %v", got, a)
+	}
+}
+
+func TestMultiplyDimensionMismatch(t *testing.T) {
+	a := mustMatrix(t, [][]float64{{1, 2, 3}, {4, 5, 6}})
+	tests := []struct {
+		name string
+		m, b Matrix
+	}{
+		{"2x3 by 2x3", a, a},
+		{"empty by matrix", nil, a},
+		{"matrix by empty", a, Matrix{}},
+		{"empty by empty", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.m.Multiply(tt.b); !errors.Is(err, ErrDimension) {
+				t.Errorf("err = %v, want ErrDimension", err)
+			}
+		})
+	}
+}
+
+func TestTranspose(t *testing.T) {
+	a := mustMatrix(t, [][]float64{{1, 2, 3}, {4, 5, 6}})
+	want := Matrix{{1, 4}, {2, 5}, {3, 6}}
+	if got := a.Transpose(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Transpose = %v, want %v", got, want)
+	}
+	if got := a.Transpose().Transpose(); !reflect.DeepEqual(got, a) {
+		t.Errorf("Transpose twice = %v, want %v", got, a)
+	}
+}
+
+func TestNewMatrix(t *testing.T) {
+	for _, rows := range [][][]float64{nil, {}, {{}}, {{1, 2}, {3}}} {
+		if _, err := NewMatrix(rows); err == nil {
+			t.Errorf("NewMatrix(%v): want an error", rows)
+		}
+	}
+
+	rows := [][]float64{{1, 2}}
+	m := mustMatrix(t, rows)
+	rows[0][0] = 99
+	if m[0][0] != 1 {
+		t.Error("NewMatrix did not copy its rows")
+	}
+}
+
+func TestString(t *testing.T) {
+	m := mustMatrix(t, [][]float64{{1, -2.25}, {10, 0}})
+	want := "   1.0   -2.2\n  10.0    0.0\n"
+	if got := m.String(); got != want {
+		t.Errorf("String = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,91 @@
+/*
+An event bus lets parts of a program talk without knowing about each other:
+publishers send events to a topic name, and every subscriber of that topic
+gets a copy on its own channel.
+
+A slow subscriber must not hold everybody else up, so each subscriber gets a
+buffered channel and Publish never waits: when a buffer is full the event is
+dropped for that subscriber only, and counted in Dropped.
+
+Publish holds the read lock while sending and Unsubscribe takes the write
+lock before closing a channel, so a channel can never be closed while an
+event is being sent on it (which would panic).
+*/
+package main
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// BufferSize is how many events a subscriber can fall behind before new
+// ones are dropped.
+const BufferSize = 16
+
+type Event struct {
+	Topic string
+	Data  any
+}
+
+// Bus delivers events to subscribers. The zero value is ready to use.
+type Bus struct {
+	mu   sync.RWMutex
+	subs map[string][]chan Event
+	// dropped is atomic because many Publish calls can run together, all
+	// holding only the read lock.
+	dropped atomic.Int64
+}
+
+// Subscribe returns a channel receiving every event published to topic from
+// now on. The channel is closed by Unsubscribe.
+func (b *Bus) Subscribe(topic string) <-chan Event {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.subs == nil {
+		b.subs = make(map[string][]chan Event)
+	}
+	ch := make(chan Event, BufferSize)
+	b.subs[topic] = append(b.subs[topic], ch)
+	return ch
+}
+
+// Unsubscribe stops the delivery to ch and closes it, so a range loop over
+// ch ends once the events already buffered are read. Unsubscribing a
+// channel twice, or from the wrong topic, does nothing.
+func (b *Bus) Unsubscribe(topic string, ch <-chan Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	subs := b.subs[topic]
+	for i, sub := range subs {
+		if sub == ch {
+			close(sub)
+			b.subs[topic] = append(subs[:i], subs[i+1:]...)
+			if len(b.subs[topic]) == 0 {
+				delete(b.subs, topic)
+			}
+			return
+		}
+	}
+}
+
+// Publish sends e to every current subscriber of topic without blocking.
+// Publishing to a topic with no subscribers does nothing.
+func (b *Bus) Publish(topic string, e Event) {
+	e.Topic = topic
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, ch := range b.subs[topic] {
+		select {
+		case ch <- e:
+		default:
+			b.dropped.Add(1)
+		}
+	}
+}
+
+// Dropped returns how many events were thrown away because a subscriber's
+// buffer was full.
+func (b *Bus) Dropped() int {
+	return int(b.dropped.Load())
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"slices"
+	"sync"
+	"testing"
+)
+
+// drain reads ch until it is closed and returns the event data.
+func drain(ch <-chan Event) []any {
+	var got []any
+	for e := range ch {
+		got = append(got, e.Data)
+	}
+	return got
+}
+
+func TestBusMultipleSubscribers(t *testing.T) {
+	var bus Bus
+	first := bus.Subscribe("orders")
+	second := bus.Subscribe("orders")
+	other := bus.Subscribe("payments")
+
+	bus.Publish("orders", Event{Data: 1})
+	bus.Publish("payments", Event{Data: "p"})
+	bus.Publish("orders", Event{Data: 2})
+
+	bus.Unsubscribe("orders", first)
+	bus.Unsubscribe("orders", second)
+	bus.Unsubscribe("payments", other)
+
+	for name, tt := range map[string]struct {
+		ch   <-chan Event
+		want []any
+	}{
+		"first":  {first, []any{1, 2}},
+		"second": {second, []any{1, 2}},
+		"other":  {other, []any{"p"}},
+	} {
+		if got := drain(tt.ch); !slices.Equal(got, tt.want) {
+			t.Errorf("%s got %v, want %v", name, got, tt.want)
+		}
+	}
+}
+
+func TestBusEventTopic(t *testing.T) {
+	var bus Bus
+	ch := bus.Subscribe("orders")
+	bus.Publish("orders", Event{Topic: "wrong", Data: 1})
+	if e := <-ch; e.Topic != "orders" {
+		t.Errorf("Topic = %q, want %q", e.Topic, "orders")
+	}
+}
+
+func TestBusUnsubscribeCleanup(t *testing.T) {
+	var bus Bus
+	a := bus.Subscribe("orders")
+	b := bus.Subscribe("orders")
+
+	bus.Unsubscribe("orders", a)
+	if n := len(bus.subs["orders"]); n != 1 {
+		t.Fatalf("%d subscribers left, want 1", n)
+	}
+	// Twice, or from the wrong topic: nothing happens, and nothing panics.
+	bus.Unsubscribe("orders", a)
+	bus.Unsubscribe("payments", b)
+
+	bus.Publish("orders", Event{Data: "after"})
+	if _, ok := <-a; ok {
+		t.Error("an unsubscribed channel received an event")
+	}
+	if e := <-b; e.Data != "after" {
+		t.Errorf("b got %v, want %q", e.Data, "after")
+	}
+
+	bus.Unsubscribe("orders", b)
+	if _, ok := bus.subs["orders"]; ok {
+		t.Error("the topic is still in the map after its last subscriber left")
+	}
+}
+
+func TestBusDropsWhenFull(t *testing.T) {
+	var bus Bus
+	slow := bus.Subscribe("news")
+	fast := bus.Subscribe("news")
+
+	var got []any
+	for i := 0; i < BufferSize+5; i++ {
+		bus.Publish("news", Event{Data: i})
+		got = append(got, (<-fast).Data)
+	}
+	if len(slow) != BufferSize || bus.Dropped() != 5 {
+		t.Errorf("buffered = %d, dropped = %d, want %d and 5", len(slow), bus.Dropped(), BufferSize)
+	}
+	if len(got) != BufferSize+5 {
+		t.Errorf("the fast subscriber got %d events, want %d", len(got), BufferSize+5)
+	}
+}
+
+// Run with go test -race *.go: publishing, subscribing and unsubscribing at
+// the same time must not race or send on a closed channel.
+func TestBusConcurrent(t *testing.T) {
+	var bus Bus
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			ch := bus.Subscribe("topic")
+			done := make(chan struct{})
+			go func() {
+				drain(ch)
+				close(done)
+			}()
+			bus.Unsubscribe("topic", ch)
+			<-done
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				bus.Publish("topic", Event{Data: j})
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := len(bus.subs["topic"]); n != 0 {
+		t.Errorf("%d subscribers left", n)
+	}
+}
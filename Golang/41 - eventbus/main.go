@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+)
+
+func main() {
+	var bus Bus
+
+	// Nobody listens yet: the event simply goes nowhere.
+	bus.Publish("orders", Event{Data: "order #0"})
+
+	first := bus.Subscribe("orders")
+	second := bus.Subscribe("orders")
+	other := bus.Subscribe("payments")
+
+	var wg sync.WaitGroup
+	for name, ch := range map[string]<-chan Event{"first": first, "second": second, "other": other} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// The loop ends when Unsubscribe closes the channel.
+			for e := range ch {
+				fmt.Printf("%s got %v on %s\n", name, e.Data, e.Topic)
+			}
+			fmt.Println(name, "unsubscribed")
+		}()
+	}
+
+	bus.Publish("orders", Event{Data: "order #1"})
+	bus.Publish("payments", Event{Data: "payment #1"})
+
+	bus.Unsubscribe("orders", first)
+	bus.Unsubscribe("orders", second)
+	bus.Unsubscribe("payments", other)
+	// Unsubscribing again is harmless.
+	bus.Unsubscribe("orders", first)
+	wg.Wait()
+
+	// A subscriber that never reads loses what does not fit in its buffer.
+	slow := bus.Subscribe("news")
+	for i := 0; i < BufferSize+5; i++ {
+		bus.Publish("news", Event{Data: i})
+	}
+	fmt.Println("buffered:", len(slow), "dropped:", bus.Dropped())
+}
@@ -0,0 +1,145 @@
+/*
+A goroutine that is never going to finish, for example because it waits on a
+channel nobody will ever write, is a leak: its memory is never freed. This
+helper catches leaks in tests by comparing the goroutines running before and
+after the test.
+
+Goroutines often need a moment to finish after the test body returns, so the
+check retries with a growing pause before deciding something leaked. It looks
+at the stack of every goroutine, not just at how many there are: a goroutine
+of the test that ends at the same moment as a new one starts would make the
+counts equal and hide the leak. Some
+goroutines belong to the runtime or to the testing package itself and come
+and go on their own; those are ignored.
+*/
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// How long to wait for goroutines to finish before reporting a leak.
+const (
+	firstPause = time.Millisecond
+	maxWait    = 2 * time.Second
+)
+
+// ignored are functions whose goroutines are not ours to worry about.
+var ignored = []string{
+	"testing.tRunner(",
+	"testing.(*T).Run(",
+	"testing.(*M).",
+	"testing.Main(",
+	"runtime.ensureSigM",
+	"os/signal.signal_recv",
+	"os/signal.loop",
+}
+
+// snapshot remembers which goroutines existed at a point in time.
+type snapshot struct {
+	ids map[string]bool
+}
+
+func takeSnapshot() snapshot {
+	s := snapshot{ids: make(map[string]bool)}
+	for _, g := range goroutines() {
+		s.ids[goroutineID(g)] = true
+	}
+	return s
+}
+
+// leaks waits for every goroutine started since the snapshot to finish and
+// returns an error with the stacks of those still running once maxWait has
+// passed.
+func (s snapshot) leaks() error {
+	pause := firstPause
+	deadline := time.Now().Add(maxWait)
+	for {
+		leaked := s.newGoroutines()
+		if len(leaked) == 0 {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%d goroutine(s) leaked:\n\n%s",
+				len(leaked), strings.Join(leaked, "\n\n"))
+		}
+		time.Sleep(pause)
+		pause = min(pause*2, 100*time.Millisecond)
+	}
+}
+
+// newGoroutines returns the stacks of the goroutines that are running now
+// but were not in the snapshot, leaving out the ignored ones.
+func (s snapshot) newGoroutines() []string {
+	var leaked []string
+	for _, g := range goroutines() {
+		if !s.ids[goroutineID(g)] && !isIgnored(g) {
+			leaked = append(leaked, g)
+		}
+	}
+	return leaked
+}
+
+// TB is the part of testing.TB that CheckNoLeaks needs. *testing.T and
+// *testing.B have these methods, so they can be passed as they are.
+type TB interface {
+	Helper()
+	Cleanup(func())
+	Errorf(format string, args ...any)
+}
+
+// CheckNoLeaks fails the test if it leaves goroutines behind. Call it at the
+// start of the test:
+//
+//	func TestWorker(t *testing.T) {
+//		CheckNoLeaks(t)
+//		...
+//	}
+//
+// Do not use it with t.Parallel(): goroutines of other tests running at the
+// same time would look like leaks.
+func CheckNoLeaks(t TB) {
+	t.Helper()
+	s := takeSnapshot()
+	t.Cleanup(func() {
+		if err := s.leaks(); err != nil {
+			t.Errorf("%v", err)
+		}
+	})
+}
+
+// goroutines returns the stack trace of every goroutine except the calling
+// one, one string each.
+func goroutines() []string {
+	buf := make([]byte, 64*1024)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+	// Traces are separated by an empty line, the first one is ourselves.
+	traces := strings.Split(string(bytes.TrimSpace(buf)), "\n\n")
+	return traces[1:]
+}
+
+// goroutineID returns the N of the "goroutine N [state]:" line of a trace.
+func goroutineID(trace string) string {
+	id, _, _ := strings.Cut(strings.TrimPrefix(trace, "goroutine "), " ")
+	return id
+}
+
+func isIgnored(trace string) bool {
+	for _, fn := range ignored {
+		if strings.Contains(trace, fn) {
+			return true
+		}
+	}
+	return false
+}
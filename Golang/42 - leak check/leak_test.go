@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeTB records what CheckNoLeaks does with the test instead of failing the
+// real one. Embedding testing.TB provides the methods it does not override.
+type fakeTB struct {
+	testing.TB
+	cleanups []func()
+	errors   []string
+}
+
+func (f *fakeTB) Helper()           {}
+func (f *fakeTB) Cleanup(fn func()) { f.cleanups = append(f.cleanups, fn) }
+func (f *fakeTB) Errorf(format string, args ...any) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+// finish runs the cleanups in reverse order, like the testing package does
+// when a test ends.
+func (f *fakeTB) finish() {
+	for i := len(f.cleanups) - 1; i >= 0; i-- {
+		f.cleanups[i]()
+	}
+}
+
+func TestCheckNoLeaksClean(t *testing.T) {
+	fake := &fakeTB{TB: t}
+	CheckNoLeaks(fake)
+
+	done := make(chan struct{})
+	go func() {
+		time.Sleep(20 * time.Millisecond) // finishes a bit late, still fine
+		close(done)
+	}()
+	fake.finish()
+
+	if len(fake.errors) != 0 {
+		t.Errorf("clean test reported: %v", fake.errors)
+	}
+}
+
+func TestCheckNoLeaksLeaking(t *testing.T) {
+	never := make(chan int)
+	defer close(never) // lets the goroutine end once the check is over
+
+	fake := &fakeTB{TB: t}
+	CheckNoLeaks(fake)
+	go func() {
+		<-never // nobody sends: stuck until the test closes the channel
+	}()
+	fake.finish()
+
+	if len(fake.errors) != 1 {
+		t.Fatalf("got %d errors, want 1: %v", len(fake.errors), fake.errors)
+	}
+	msg := fake.errors[0]
+	if !strings.Contains(msg, "1 goroutine(s) leaked") || !strings.Contains(msg, "TestCheckNoLeaksLeaking") {
+		t.Errorf("report does not show the leaked goroutine:\n%s", msg)
+	}
+}
+
+// A goroutine that ends just as another one starts keeps the count the same,
+// but the new one is still a leak.
+func TestLeaksSameCount(t *testing.T) {
+	old := make(chan struct{})
+	oldDone := make(chan struct{})
+	go func() {
+		<-old
+		close(oldDone)
+	}()
+
+	s := takeSnapshot()
+	never := make(chan struct{})
+	defer close(never)
+	close(old)
+	<-oldDone
+	go func() { <-never }()
+
+	leaked := s.newGoroutines()
+	if len(leaked) != 1 {
+		t.Errorf("found %d new goroutines, want 1", len(leaked))
+	}
+}
+
+func TestCheckNoLeaksWithRealTest(t *testing.T) {
+	CheckNoLeaks(t)
+	done := make(chan struct{})
+	go func() { close(done) }()
+	<-done
+}
@@ -0,0 +1,18 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+func main() {
+	// In a test, CheckNoLeaks(t) does these two steps for you. The tests in
+	// leak_test.go also show what a leak report looks like.
+	s := takeSnapshot()
+	done := make(chan struct{})
+	go func() {
+		time.Sleep(20 * time.Millisecond) // finishes a bit late, still fine
+		close(done)
+	}()
+	fmt.Println("leaks:", s.leaks())
+}
@@ -0,0 +1,248 @@
+/*
+A calculator REPL (read, evaluate, print, loop): type an expression such as
+3 + 4 * 2 and it prints 11. Lines starting with ":" are commands, like :help
+and :quit, looked up in a table of functions.
+
+Eval uses the shunting-yard algorithm: numbers go straight to the output,
+operators wait on a stack until an operator of lower precedence (or a closing
+parenthesis) pushes them out. The output ends up in postfix order, "3 4 2 * +",
+which is easy to evaluate with a second stack.
+*/
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ErrDivisionByZero is returned by Eval for expressions like 1 / 0.
+var ErrDivisionByZero = errors.New("division by zero")
+
+type tokenKind int
+
+const (
+	number tokenKind = iota
+	operator
+	leftParen
+	rightParen
+)
+
+type token struct {
+	kind  tokenKind
+	value float64 // for numbers
+	op    byte    // for operators: + - * / and 'n' for a unary minus
+	pos   int     // 1-based position in the expression, for error messages
+}
+
+// precedence returns how tightly op binds: a higher number is applied first.
+func precedence(op byte) int {
+	switch op {
+	case '+', '-':
+		return 1
+	case '*', '/':
+		return 2
+	default: // 'n', unary minus
+		return 3
+	}
+}
+
+// tokenize splits expr into tokens, skipping spaces. A "-" where a number is
+// expected, like in "-3" or "2 * -3", becomes the unary minus 'n'.
+func tokenize(expr string) ([]token, error) {
+	var tokens []token
+	expectOperand := true
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+		case c >= '0' && c <= '9' || c == '.':
+			start := i
+			for i < len(expr) && (expr[i] >= '0' && expr[i] <= '9' || expr[i] == '.') {
+				i++
+			}
+			v, err := strconv.ParseFloat(expr[start:i], 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number %q at position %d", expr[start:i], start+1)
+			}
+			if !expectOperand {
+				return nil, fmt.Errorf("unexpected number at position %d", start+1)
+			}
+			tokens = append(tokens, token{kind: number, value: v, pos: start + 1})
+			expectOperand = false
+		case c == '(':
+			if !expectOperand {
+				return nil, fmt.Errorf("unexpected '(' at position %d", i+1)
+			}
+			tokens = append(tokens, token{kind: leftParen, pos: i + 1})
+			i++
+		case c == ')':
+			if expectOperand {
+				return nil, fmt.Errorf("unexpected ')' at position %d", i+1)
+			}
+			tokens = append(tokens, token{kind: rightParen, pos: i + 1})
+			i++
+		case c == '-' && expectOperand:
+			tokens = append(tokens, token{kind: operator, op: 'n', pos: i + 1})
+			i++
+		case c == '+' || c == '-' || c == '*' || c == '/':
+			if expectOperand {
+				return nil, fmt.Errorf("unexpected %q at position %d", c, i+1)
+			}
+			tokens = append(tokens, token{kind: operator, op: c, pos: i + 1})
+			expectOperand = true
+			i++
+		default:
+			return nil, fmt.Errorf("unexpected character %q at position %d", c, i+1)
+		}
+	}
+	if expectOperand {
+		return nil, errors.New("unexpected end of expression")
+	}
+	return tokens, nil
+}
+
+// toPostfix reorders tokens with the shunting-yard algorithm.
+func toPostfix(tokens []token) ([]token, error) {
+	var out, stack []token
+	for _, t := range tokens {
+		switch t.kind {
+		case number:
+			out = append(out, t)
+		case operator:
+			for len(stack) > 0 {
+				top := stack[len(stack)-1]
+				if top.kind != operator {
+					break
+				}
+				// Left-associative operators (all but unary minus) also pop
+				// operators of the same precedence: 8 - 3 - 2 is (8 - 3) - 2.
+				if precedence(top.op) > precedence(t.op) ||
+					precedence(top.op) == precedence(t.op) && t.op != 'n' {
+					out = append(out, top)
+					stack = stack[:len(stack)-1]
+					continue
+				}
+				break
+			}
+			stack = append(stack, t)
+		case leftParen:
+			stack = append(stack, t)
+		case rightParen:
+			for {
+				if len(stack) == 0 {
+					return nil, fmt.Errorf("unmatched ')' at position %d", t.pos)
+				}
+				top := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				if top.kind == leftParen {
+					break
+				}
+				out = append(out, top)
+			}
+		}
+	}
+	for len(stack) > 0 {
+		top := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if top.kind == leftParen {
+			return nil, fmt.Errorf("unmatched '(' at position %d", top.pos)
+		}
+		out = append(out, top)
+	}
+	return out, nil
+}
+
+// Eval computes the value of an expression made of numbers, + - * /, unary
+// minus and parentheses, with the usual precedence. Spaces are ignored.
+func Eval(expr string) (float64, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return 0, err
+	}
+	postfix, err := toPostfix(tokens)
+	if err != nil {
+		return 0, err
+	}
+
+	// tokenize already checked that operators and operands alternate, so
+	// the stack always holds enough values here.
+	var stack []float64
+	for _, t := range postfix {
+		if t.kind == number {
+			stack = append(stack, t.value)
+			continue
+		}
+		if t.op == 'n' {
+			stack[len(stack)-1] = -stack[len(stack)-1]
+			continue
+		}
+		a, b := stack[len(stack)-2], stack[len(stack)-1]
+		stack = stack[:len(stack)-2]
+		var r float64
+		switch t.op {
+		case '+':
+			r = a + b
+		case '-':
+			r = a - b
+		case '*':
+			r = a * b
+		case '/':
+			if b == 0 {
+				return 0, fmt.Errorf("%w at position %d", ErrDivisionByZero, t.pos)
+			}
+			r = a / b
+		}
+		stack = append(stack, r)
+	}
+	return stack[0], nil
+}
+
+// commands are the REPL commands. Each one returns false to stop the loop.
+var commands = map[string]func() bool{
+	":help": func() bool {
+		fmt.Println("Type an expression like (1 + 2) * 3, or :quit to leave.")
+		return true
+	},
+	":quit": func() bool {
+		return false
+	},
+}
+
+func main() {
+	fmt.Println("Calculator, type :help for help.")
+	sc := bufio.NewScanner(os.Stdin)
+	for {
+		fmt.Print("> ")
+		if !sc.Scan() {
+			fmt.Println()
+			return
+		}
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			cmd, ok := commands[line]
+			if !ok {
+				fmt.Println("unknown command", line)
+				continue
+			}
+			if !cmd() {
+				return
+			}
+			continue
+		}
+
+		v, err := Eval(line)
+		if err != nil {
+			fmt.Println("error:", err)
+			continue
+		}
+		fmt.Println(strconv.FormatFloat(v, 'g', -1, 64))
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestEval(t *testing.T) {
+	tests := []struct {
+		expr string
+		want float64
+	}{
+		{"42", 42},
+		{"1.5", 1.5},
+		{"3 + 4 * 2", 11},
+		{"(3 + 4) * 2", 14},
+		{"8 - 3 - 2", 3},
+		{"8 / 4 / 2", 1},
+		{"-3", -3},
+		{"2 * -3", -6},
+		{"--3", 3},
+		{"-(1 + 2) * 3", -9},
+		{"  1+2  ", 3},
+		{"((2))", 2},
+		{"1 / 4", 0.25},
+	}
+	for _, tt := range tests {
+		got, err := Eval(tt.expr)
+		if err != nil {
+			t.Errorf("Eval(%q): %v", tt.expr, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Eval(%q) = %g, want %g", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestEvalErrors(t *testing.T) {
+	tests := []struct {
+		expr    string
+		wantMsg string // part of the error message
+	}{
+		{"", "unexpected end"},
+		{"1 +", "unexpected end"},
+		{"1 2", "unexpected number at position 3"},
+		{"* 2", "unexpected '*' at position 1"},
+		{"(1 + 2", "unmatched '(' at position 1"},
+		{"1 + 2)", "unmatched ')' at position 6"},
+		{"()", "unexpected ')' at position 2"},
+		{"1.2.3", "invalid number"},
+		{"2 ^ 3", "unexpected character '^' at position 3"},
+		{"1 / 0", "division by zero at position 3"},
+	}
+	for _, tt := range tests {
+		_, err := Eval(tt.expr)
+		if err == nil || !strings.Contains(err.Error(), tt.wantMsg) {
+			t.Errorf("Eval(%q) error = %v, want one containing %q", tt.expr, err, tt.wantMsg)
+		}
+	}
+	if _, err := Eval("1 / (2 - 2)"); !errors.Is(err, ErrDivisionByZero) {
+		t.Errorf("Eval(1 / (2 - 2)) = %v, want ErrDivisionByZero", err)
+	}
+}
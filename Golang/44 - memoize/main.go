@@ -0,0 +1,34 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+func main() {
+	slowSquare := func(n int) int {
+		time.Sleep(100 * time.Millisecond)
+		return n * n
+	}
+	square := Memoize(slowSquare)
+
+	start := time.Now()
+	fmt.Println(square(12), "first call took", time.Since(start).Round(10*time.Millisecond))
+	start = time.Now()
+	fmt.Println(square(12), "second call took", time.Since(start).Round(10*time.Millisecond))
+
+	// 100 goroutines ask for the same new key at the same time, and still
+	// wait for a single call of slowSquare.
+	start = time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			square(7)
+		}()
+	}
+	wg.Wait()
+	fmt.Println("100 goroutines took", time.Since(start).Round(10*time.Millisecond))
+}
@@ -0,0 +1,45 @@
+/*
+Memoization remembers the result of a slow function for every argument it
+has seen, so calling it again with the same argument returns at once.
+
+With many goroutines a simple cache still has a gap: two callers asking for
+the same new key at the same moment would both miss the cache and both run
+the slow function. Here the first caller leaves a "call in progress" entry
+in the map before computing, and later callers for that key wait for it to
+finish instead of starting their own. This is often called single-flight.
+*/
+package main
+
+import "sync"
+
+// call is the result of fn for one key, filled in once done is closed.
+type call[V any] struct {
+	done  chan struct{}
+	value V
+}
+
+// Memoize returns a function that behaves like fn but calls fn at most once
+// per key, even when used from many goroutines at once. Results are kept
+// forever, so the set of keys should be bounded. fn must not panic:
+// callers waiting for the same key would wait forever.
+func Memoize[K comparable, V any](fn func(K) V) func(K) V {
+	var mu sync.Mutex
+	calls := make(map[K]*call[V])
+
+	return func(k K) V {
+		mu.Lock()
+		if c, ok := calls[k]; ok {
+			mu.Unlock()
+			<-c.done // already computed, or being computed by someone else
+			return c.value
+		}
+		c := &call[V]{done: make(chan struct{})}
+		calls[k] = c
+		// fn runs without the lock held, so other keys are not held up.
+		mu.Unlock()
+
+		c.value = fn(k)
+		close(c.done)
+		return c.value
+	}
+}
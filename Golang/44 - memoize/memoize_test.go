@@ -0,0 +1,73 @@
+package main
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMemoizeCallsOncePerKey(t *testing.T) {
+	var calls atomic.Int64
+	square := Memoize(func(n int) int {
+		calls.Add(1)
+		return n * n
+	})
+
+	for _, n := range []int{3, 3, -3, 3, 0, -3} {
+		if got := square(n); got != n*n {
+			t.Errorf("square(%d) = %d, want %d", n, got, n*n)
+		}
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("fn called %d times for 3 distinct keys", got)
+	}
+}
+
+func TestMemoizeSecondCallIsFast(t *testing.T) {
+	const delay = 200 * time.Millisecond
+	slow := Memoize(func(s string) int {
+		time.Sleep(delay)
+		return len(s)
+	})
+
+	start := time.Now()
+	slow("gopher")
+	if first := time.Since(start); first < delay {
+		t.Fatalf("first call took %v, want at least %v", first, delay)
+	}
+
+	start = time.Now()
+	if got := slow("gopher"); got != 6 {
+		t.Errorf("second call = %d, want 6", got)
+	}
+	// Half the delay leaves plenty of room for a slow machine.
+	if second := time.Since(start); second > delay/2 {
+		t.Errorf("second call took %v, want it served from the cache", second)
+	}
+}
+
+// Run with go test -race *.go.
+func TestMemoizeSingleFlight(t *testing.T) {
+	var calls atomic.Int64
+	square := Memoize(func(n int) int {
+		calls.Add(1)
+		time.Sleep(50 * time.Millisecond) // keep the call in progress
+		return n * n
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if got := square(7); got != 49 {
+				t.Errorf("square(7) = %d", got)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := calls.Load(); got != 1 {
+		t.Errorf("fn called %d times for 100 concurrent callers, want 1", got)
+	}
+}
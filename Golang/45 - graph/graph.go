@@ -0,0 +1,109 @@
+/*
+A graph stored as an adjacency list: for every node, the list of nodes it
+has an edge to.
+
+Breadth-first search (BFS) visits the start node, then all its neighbors,
+then their neighbors, and so on, using a queue. Depth-first search (DFS)
+follows one path as deep as it goes before backing up, using a stack (here
+the call stack, through recursion).
+
+Both visit the neighbors of a node in the order of their list. To get the
+same order on every run, the lists are kept sorted with the less function
+given to NewGraph, like the binary tree example does.
+*/
+package main
+
+import "slices"
+
+// Graph is a directed or undirected graph. Create it with NewGraph.
+type Graph[T comparable] struct {
+	directed bool
+	less     func(a, b T) bool
+	adj      map[T][]T
+}
+
+// NewGraph returns an empty graph. In an undirected graph AddEdge(a, b) also
+// adds the edge from b back to a. less orders the neighbors of each node.
+func NewGraph[T comparable](directed bool, less func(a, b T) bool) *Graph[T] {
+	return &Graph[T]{directed: directed, less: less, adj: make(map[T][]T)}
+}
+
+// AddEdge adds an edge from a to b, adding the nodes if needed. Adding an
+// edge that already exists does nothing.
+func (g *Graph[T]) AddEdge(a, b T) {
+	g.addArc(a, b)
+	if g.directed {
+		// b must still be known as a node, even with no edges of its own.
+		if _, ok := g.adj[b]; !ok {
+			g.adj[b] = nil
+		}
+		return
+	}
+	g.addArc(b, a)
+}
+
+func (g *Graph[T]) addArc(from, to T) {
+	neighbors := g.adj[from]
+	if slices.Contains(neighbors, to) {
+		return
+	}
+	neighbors = append(neighbors, to)
+	slices.SortFunc(neighbors, func(x, y T) int {
+		switch {
+		case g.less(x, y):
+			return -1
+		case g.less(y, x):
+			return 1
+		default:
+			return 0
+		}
+	})
+	g.adj[from] = neighbors
+}
+
+// BFS returns the nodes reachable from start in breadth-first order,
+// starting with start itself. A start that is not in the graph gives an
+// empty slice.
+func (g *Graph[T]) BFS(start T) []T {
+	order := []T{}
+	if _, ok := g.adj[start]; !ok {
+		return order
+	}
+	visited := map[T]bool{start: true}
+	queue := []T{start}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		order = append(order, n)
+		for _, next := range g.adj[n] {
+			if !visited[next] {
+				visited[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	return order
+}
+
+// DFS returns the nodes reachable from start in depth-first order,
+// starting with start itself. A start that is not in the graph gives an
+// empty slice.
+func (g *Graph[T]) DFS(start T) []T {
+	order := []T{}
+	if _, ok := g.adj[start]; !ok {
+		return order
+	}
+	visited := make(map[T]bool)
+	var visit func(n T)
+	visit = func(n T) {
+		visited[n] = true
+		order = append(order, n)
+		for _, next := range g.adj[n] {
+			if !visited[next] {
+				visit(next)
+			}
+		}
+	}
+	visit(start)
+	return order
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+// roads is the undirected graph drawn in main:
+//
+//	A --- B --- D
+//	|     |
+//	C --- E --- F
+func roads() *Graph[string] {
+	g := NewGraph(false, func(a, b string) bool { return a < b })
+	for _, e := range [][2]string{{"A", "C"}, {"A", "B"}, {"B", "D"}, {"B", "E"}, {"C", "E"}, {"E", "F"}} {
+		g.AddEdge(e[0], e[1])
+	}
+	return g
+}
+
+func tasks() *Graph[int] {
+	g := NewGraph(true, func(a, b int) bool { return a < b })
+	g.AddEdge(1, 3)
+	g.AddEdge(1, 2)
+	g.AddEdge(3, 4)
+	g.AddEdge(2, 4)
+	g.AddEdge(2, 4) // duplicate, ignored
+	return g
+}
+
+func TestSearchOrder(t *testing.T) {
+	tests := []struct {
+		start    string
+		bfs, dfs []string
+	}{
+		{"A", []string{"A", "B", "C", "D", "E", "F"}, []string{"A", "B", "D", "E", "C", "F"}},
+		{"F", []string{"F", "E", "B", "C", "A", "D"}, []string{"F", "E", "B", "A", "C", "D"}},
+		{"D", []string{"D", "B", "A", "E", "C", "F"}, []string{"D", "B", "A", "C", "E", "F"}},
+	}
+	g := roads()
+	for _, tt := range tests {
+		t.Run("from "+tt.start, func(t *testing.T) {
+			if got := g.BFS(tt.start); !slices.Equal(got, tt.bfs) {
+				t.Errorf("BFS = %v, want %v", got, tt.bfs)
+			}
+			if got := g.DFS(tt.start); !slices.Equal(got, tt.dfs) {
+				t.Errorf("DFS = %v, want %v", got, tt.dfs)
+			}
+		})
+	}
+}
+
+func TestSearchDirected(t *testing.T) {
+	g := tasks()
+	if got, want := g.BFS(1), []int{1, 2, 3, 4}; !slices.Equal(got, want) {
+		t.Errorf("BFS(1) = %v, want %v", got, want)
+	}
+	if got, want := g.DFS(1), []int{1, 2, 4, 3}; !slices.Equal(got, want) {
+		t.Errorf("DFS(1) = %v, want %v", got, want)
+	}
+	// Edges are one way: nothing is reachable from 4 but itself.
+	if got := g.BFS(4); !slices.Equal(got, []int{4}) {
+		t.Errorf("BFS(4) = %v, want [4]", got)
+	}
+	if got := g.DFS(3); !slices.Equal(got, []int{3, 4}) {
+		t.Errorf("DFS(3) = %v, want [3 4]", got)
+	}
+}
+
+func TestSearchMissingStart(t *testing.T) {
+	g := roads()
+	for name, got := range map[string][]string{"BFS": g.BFS("Z"), "DFS": g.DFS("Z")} {
+		if got == nil || len(got) != 0 {
+			t.Errorf("%s(Z) = %#v, want an empty, non-nil slice", name, got)
+		}
+	}
+	empty := NewGraph(false, func(a, b int) bool { return a < b })
+	if got := empty.BFS(0); len(got) != 0 {
+		t.Errorf("BFS on an empty graph = %v", got)
+	}
+}
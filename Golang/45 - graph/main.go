@@ -0,0 +1,26 @@
+package main
+
+import "fmt"
+
+func main() {
+	//   A --- B --- D
+	//   |     |
+	//   C --- E --- F
+	roads := NewGraph(false, func(a, b string) bool { return a < b })
+	for _, e := range [][2]string{{"A", "C"}, {"A", "B"}, {"B", "D"}, {"B", "E"}, {"C", "E"}, {"E", "F"}} {
+		roads.AddEdge(e[0], e[1])
+	}
+	fmt.Println("BFS from A:", roads.BFS("A"))
+	fmt.Println("DFS from A:", roads.DFS("A"))
+	fmt.Println("BFS from Z:", roads.BFS("Z"))
+
+	// In a directed graph edges are one-way streets.
+	tasks := NewGraph(true, func(a, b int) bool { return a < b })
+	tasks.AddEdge(1, 2)
+	tasks.AddEdge(1, 3)
+	tasks.AddEdge(3, 4)
+	tasks.AddEdge(2, 4)
+	fmt.Println("directed BFS from 1:", tasks.BFS(1))
+	fmt.Println("directed DFS from 1:", tasks.DFS(1))
+	fmt.Println("directed BFS from 4:", tasks.BFS(4))
+}
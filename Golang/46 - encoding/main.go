@@ -0,0 +1,85 @@
+/*
+Turning bytes into text and back, and fingerprinting them with a hash.
+
+  - Hex writes every byte as two characters 0-9a-f: easy to read, but twice
+    the size.
+  - Base64 uses 64 characters, 4 for every 3 bytes. When the length is not
+    a multiple of 3 the end is filled with "=" (padding); StdEncoding
+    requires that padding, RawStdEncoding leaves it out.
+  - SHA-256 is not an encoding: it cannot be reversed. It turns any input
+    into 32 bytes that change completely if a single input bit changes,
+    which makes it useful to check that a file was not modified.
+*/
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+)
+
+func EncodeHex(data []byte) string {
+	return hex.EncodeToString(data)
+}
+
+// DecodeHex returns the bytes written in s, which must have an even number
+// of hex digits.
+func DecodeHex(s string) ([]byte, error) {
+	data, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("decode hex: %w", err)
+	}
+	return data, nil
+}
+
+func EncodeBase64(data []byte) string {
+	return base64.StdEncoding.EncodeToString(data)
+}
+
+// DecodeBase64 returns the bytes written in s, which must be padded
+// standard base64.
+func DecodeBase64(s string) ([]byte, error) {
+	data, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("decode base64: %w", err)
+	}
+	return data, nil
+}
+
+// SHA256Hex returns the SHA-256 digest of data as 64 hex digits.
+func SHA256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func main() {
+	msg := []byte("hello")
+	fmt.Println("hex:    ", EncodeHex(msg))
+	fmt.Println("base64: ", EncodeBase64(msg))
+	fmt.Println("sha256: ", SHA256Hex(msg))
+
+	// 1, 2 and 3 bytes: see how the padding changes.
+	for _, s := range []string{"a", "ab", "abc"} {
+		fmt.Printf("base64(%q) = %s\n", s, EncodeBase64([]byte(s)))
+	}
+
+	// Random bytes survive the round trip through both encodings.
+	random := make([]byte, 20)
+	rand.Read(random)
+	fromHex, _ := DecodeHex(EncodeHex(random))
+	fromBase64, _ := DecodeBase64(EncodeBase64(random))
+	fmt.Println("round trips ok:", bytes.Equal(random, fromHex), bytes.Equal(random, fromBase64))
+
+	if _, err := DecodeHex("abc"); err != nil {
+		fmt.Println("Error:", err)
+	}
+	if _, err := DecodeHex("zz"); err != nil {
+		fmt.Println("Error:", err)
+	}
+	if _, err := DecodeBase64("aGVsbG8"); err != nil {
+		fmt.Println("Error:", err)
+	}
+}
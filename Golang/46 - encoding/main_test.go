@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+func TestRoundTripRandomBytes(t *testing.T) {
+	for _, size := range []int{0, 1, 2, 3, 20, 1000} {
+		data := make([]byte, size)
+		if _, err := rand.Read(data); err != nil {
+			t.Fatal(err)
+		}
+
+		fromHex, err := DecodeHex(EncodeHex(data))
+		if err != nil || !bytes.Equal(fromHex, data) {
+			t.Errorf("hex round trip of %d bytes: %x, %v", size, fromHex, err)
+		}
+		fromBase64, err := DecodeBase64(EncodeBase64(data))
+		if err != nil || !bytes.Equal(fromBase64, data) {
+			t.Errorf("base64 round trip of %d bytes: %x, %v", size, fromBase64, err)
+		}
+	}
+}
+
+func TestKnownValues(t *testing.T) {
+	tests := []struct {
+		in, hex, base64 string
+	}{
+		{"", "", ""},
+		{"a", "61", "YQ=="},
+		{"ab", "6162", "YWI="},
+		{"abc", "616263", "YWJj"},
+		{"hello", "68656c6c6f", "aGVsbG8="},
+	}
+	for _, tt := range tests {
+		if got := EncodeHex([]byte(tt.in)); got != tt.hex {
+			t.Errorf("EncodeHex(%q) = %q, want %q", tt.in, got, tt.hex)
+		}
+		if got := EncodeBase64([]byte(tt.in)); got != tt.base64 {
+			t.Errorf("EncodeBase64(%q) = %q, want %q", tt.in, got, tt.base64)
+		}
+	}
+}
+
+func TestSHA256Hex(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"hello", "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"},
+		{"", "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+	}
+	for _, tt := range tests {
+		if got := SHA256Hex([]byte(tt.in)); got != tt.want {
+			t.Errorf("SHA256Hex(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+	if SHA256Hex([]byte("hello")) == SHA256Hex([]byte("hellp")) {
+		t.Error("one changed byte gave the same digest")
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	if _, err := DecodeHex("abc"); !errors.Is(err, hex.ErrLength) {
+		t.Errorf(`DecodeHex("abc") err = %v, want hex.ErrLength`, err)
+	}
+	var invalid hex.InvalidByteError
+	if _, err := DecodeHex("zz"); !errors.As(err, &invalid) || invalid != 'z' {
+		t.Errorf(`DecodeHex("zz") err = %v, want InvalidByteError('z')`, err)
+	}
+	if _, err := DecodeBase64("aGVsbG8"); err == nil {
+		t.Error(`DecodeBase64 without padding: want an error`)
+	}
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+func main() {
+	sales := NewReport("Product", "Quantity", "Price", "Notes")
+	sales.SetTotal(1)
+	sales.SetTotal(2)
+	sales.AddRow("Notebook", "12", "3.50", "A5, lined")
+	sales.AddRow("Pen", "150", "1.2", "blue")
+	sales.AddRow("Backpack", "3", "25.00")
+	sales.AddRow("Crème pâtissière", "1", "4.75", "from the café")
+	if err := sales.Render(os.Stdout); err != nil {
+		fmt.Println("Error:", err)
+	}
+	fmt.Println()
+
+	// No header and no totals, just aligned columns.
+	plain := NewReport()
+	plain.SetAlign(1, Right)
+	plain.AddRow("apples", "3")
+	plain.AddRow("watermelons", "12")
+	plain.Render(os.Stdout)
+	fmt.Println()
+
+	bad := NewReport("Item", "Cost")
+	bad.SetTotal(1)
+	bad.AddRow("Lunch", "twelve")
+	if err := bad.Render(os.Stdout); err != nil {
+		fmt.Println("Error:", err)
+	}
+}
@@ -0,0 +1,173 @@
+/*
+A text report with aligned columns, an optional header and a footer with the
+totals of numeric columns.
+
+text/tabwriter lines up columns separated by tabs, but its AlignRight flag
+applies to every column at once. Numbers read best right-aligned while text
+reads best left-aligned, so Render pads right-aligned cells to the column
+width itself, counting runes so accented letters take one place, and lets
+tabwriter do the rest.
+*/
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"unicode/utf8"
+)
+
+type Align int
+
+const (
+	Left Align = iota
+	Right
+)
+
+// Report collects rows and renders them as a table. Create it with NewReport.
+type Report struct {
+	header []string
+	align  map[int]Align
+	total  map[int]bool
+	rows   [][]string
+}
+
+// NewReport returns an empty report. With no header names the table is
+// printed without a header.
+func NewReport(header ...string) *Report {
+	return &Report{header: header, align: make(map[int]Align), total: make(map[int]bool)}
+}
+
+// SetAlign sets the alignment of column col (counting from 0). Columns are
+// left-aligned unless told otherwise.
+func (r *Report) SetAlign(col int, a Align) {
+	r.align[col] = a
+}
+
+// SetTotal adds the sum of column col to the footer. The column is also
+// right-aligned, and every cell in it must be a number.
+func (r *Report) SetTotal(col int) {
+	r.total[col] = true
+	r.align[col] = Right
+}
+
+// AddRow appends a row. Rows can have fewer cells than others, the missing
+// ones are left blank.
+func (r *Report) AddRow(cols ...string) {
+	r.rows = append(r.rows, cols)
+}
+
+// Render writes the report to w.
+func (r *Report) Render(w io.Writer) error {
+	var table [][]string
+	if len(r.header) > 0 {
+		table = append(table, r.header)
+	}
+	table = append(table, r.rows...)
+
+	footer, err := r.footer()
+	if err != nil {
+		return err
+	}
+	if footer != nil {
+		table = append(table, footer)
+	}
+
+	columns := 0
+	for _, row := range table {
+		columns = max(columns, len(row))
+	}
+	widths := make([]int, columns)
+	for _, row := range table {
+		for i, cell := range row {
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+		}
+	}
+
+	// The dashes go under the header and above the totals.
+	rule := make([]string, columns)
+	for i, width := range widths {
+		rule[i] = strings.Repeat("-", width)
+	}
+	var lines [][]string
+	for i, row := range table {
+		if i == len(table)-1 && footer != nil {
+			lines = append(lines, rule)
+		}
+		lines = append(lines, row)
+		if i == 0 && len(r.header) > 0 {
+			lines = append(lines, rule)
+		}
+	}
+
+	// Every cell, the last one too, ends with a tab: tabwriter only aligns
+	// tab-terminated cells, and text after the last tab is left as it is.
+	var buf bytes.Buffer
+	tw := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	for _, row := range lines {
+		for i := 0; i < columns; i++ {
+			cell := ""
+			if i < len(row) {
+				cell = row[i]
+			}
+			if r.align[i] == Right {
+				cell = strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell)) + cell
+			}
+			tw.Write([]byte(cell + "\t"))
+		}
+		tw.Write([]byte("\n"))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	// The padding of the last column only makes trailing spaces, and it can
+	// only be removed once tabwriter has aligned everything.
+	for _, line := range strings.SplitAfter(buf.String(), "\n") {
+		if line == "" {
+			continue
+		}
+		if _, err := io.WriteString(w, strings.TrimRight(line, " \n")+"\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// footer returns the totals row, or nil when no column is totaled. Sums are
+// printed with as many decimals as the most precise cell of their column.
+func (r *Report) footer() ([]string, error) {
+	if len(r.total) == 0 {
+		return nil, nil
+	}
+	last := 0
+	for col := range r.total {
+		last = max(last, col)
+	}
+	footer := make([]string, last+1)
+	if !r.total[0] {
+		footer[0] = "Total"
+	}
+
+	for col := range r.total {
+		sum, decimals := 0.0, 0
+		for i, row := range r.rows {
+			if col >= len(row) || row[col] == "" {
+				continue
+			}
+			v, err := strconv.ParseFloat(row[col], 64)
+			if err != nil {
+				return nil, fmt.Errorf("report: row %d, column %d: %q is not a number", i, col, row[col])
+			}
+			sum += v
+			if _, frac, ok := strings.Cut(row[col], "."); ok {
+				decimals = max(decimals, len(frac))
+			}
+		}
+		footer[col] = strconv.FormatFloat(sum, 'f', decimals, 64)
+	}
+	return footer, nil
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func() *Report
+		want  string
+	}{
+		{
+			"mixed numeric and text",
+			func() *Report {
+				r := NewReport("Product", "Quantity", "Price", "Notes")
+				r.SetTotal(1)
+				r.SetTotal(2)
+				r.AddRow("Notebook", "12", "3.50", "A5, lined")
+				r.AddRow("Pen", "150", "1.2", "blue")
+				r.AddRow("Backpack", "3", "25.00")
+				r.AddRow("Crème pâtissière", "1", "4.75", "from the café")
+				return r
+			},
+			`Product           Quantity  Price  Notes
+----------------  --------  -----  -------------
+Notebook                12   3.50  A5, lined
+Pen                    150    1.2  blue
+Backpack                 3  25.00
+Crème pâtissière         1   4.75  from the café
+----------------  --------  -----  -------------
+Total                  166  34.45
+`,
+		},
+		{
+			"no header, right-aligned column",
+			func() *Report {
+				r := NewReport()
+				r.SetAlign(1, Right)
+				r.AddRow("apples", "3")
+				r.AddRow("watermelons", "12")
+				return r
+			},
+			"apples        3\nwatermelons  12\n",
+		},
+		{
+			// A short row in the middle must not break the alignment of the
+			// rows after it.
+			"short rows in the middle",
+			func() *Report {
+				r := NewReport("A", "B", "C")
+				r.AddRow("1", "2", "3")
+				r.AddRow("only")
+				r.AddRow("x", "", "long value")
+				r.AddRow("4", "5", "6")
+				return r
+			},
+			`A     B  C
+----  -  ----------
+1     2  3
+only
+x        long value
+4     5  6
+`,
+		},
+		{
+			"rows wider than the header",
+			func() *Report {
+				r := NewReport("Name")
+				r.AddRow("a", "extra", "more")
+				r.AddRow("bb")
+				return r
+			},
+			`Name
+----  -----  ----
+a     extra  more
+bb
+`,
+		},
+		{
+			"empty",
+			func() *Report { return NewReport() },
+			"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sb strings.Builder
+			if err := tt.build().Render(&sb); err != nil {
+				t.Fatal(err)
+			}
+			if sb.String() != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", sb.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderNoTrailingSpaces(t *testing.T) {
+	r := NewReport("Item", "Notes")
+	r.AddRow("a")
+	r.AddRow("bbbbbb", "n")
+	var sb strings.Builder
+	if err := r.Render(&sb); err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range strings.Split(sb.String(), "\n") {
+		if strings.HasSuffix(line, " ") || strings.HasSuffix(line, "\t") {
+			t.Errorf("line %q ends with blanks", line)
+		}
+	}
+}
+
+func TestRenderBadNumber(t *testing.T) {
+	r := NewReport("Item", "Cost")
+	r.SetTotal(1)
+	r.AddRow("Lunch", "twelve")
+	var sb strings.Builder
+	err := r.Render(&sb)
+	if err == nil || !strings.Contains(err.Error(), `row 0, column 1: "twelve" is not a number`) {
+		t.Errorf("err = %v", err)
+	}
+	if sb.Len() != 0 {
+		t.Errorf("wrote %q before failing", sb.String())
+	}
+}
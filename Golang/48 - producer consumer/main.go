@@ -0,0 +1,94 @@
+/*
+One producer puts items into a bounded (buffered) channel and several
+consumers take them out. When the buffer is full the producer waits, which
+keeps a fast producer from running far ahead of slow consumers.
+
+Shutting down in the right order avoids both losing items and deadlocks:
+ 1. the producer stops, because produce ran out of items or ctx was
+    cancelled;
+ 2. the producer closes the channel. Only the sender may close a channel,
+    and closing tells the consumers no more items are coming;
+ 3. the consumers keep going until the channel is empty (range over a closed
+    channel still returns the buffered items) and then return.
+*/
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Run starts one producer and the given number of consumers connected by a
+// channel buffering up to bufSize items, and returns how many items the
+// consumers processed. produce returns the next item, or false when there is
+// nothing left.
+//
+// Every item that made it into the buffer is processed, also after ctx is
+// cancelled. On cancellation, an item produced while the buffer was full is
+// never queued and so not counted: the producer does not wait for room once
+// ctx is done, which is what keeps cancellation from blocking.
+func Run(ctx context.Context, bufSize, consumers int, produce func() (int, bool)) (processed int) {
+	items := make(chan int, bufSize)
+	var count atomic.Int64
+
+	go func() {
+		defer close(items)
+		for ctx.Err() == nil {
+			item, ok := produce()
+			if !ok {
+				return
+			}
+			select {
+			case items <- item:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < max(consumers, 1); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range items {
+				// A real consumer would do some work with the item here.
+				time.Sleep(time.Millisecond)
+				count.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	return int(count.Load())
+}
+
+// counter returns a produce function yielding 1, 2, ..., n.
+func counter(n int) func() (int, bool) {
+	next := 0
+	return func() (int, bool) {
+		if next == n {
+			return 0, false
+		}
+		next++
+		return next, true
+	}
+}
+
+func main() {
+	// A finite producer: every item is processed.
+	fmt.Println("processed:", Run(context.Background(), 10, 4, counter(100)))
+
+	// An endless producer, stopped by a timeout.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	produced := 0
+	endless := func() (int, bool) {
+		produced++
+		return produced, true
+	}
+	processed := Run(ctx, 10, 2, endless)
+	fmt.Printf("after cancel: produced %d, processed %d\n", produced, processed)
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestRunProcessesEveryItem(t *testing.T) {
+	tests := []struct {
+		items, bufSize, consumers int
+	}{
+		{0, 10, 4},
+		{1, 1, 1},
+		{100, 10, 4},
+		{100, 0, 3}, // unbuffered
+		{50, 100, 1},
+		{30, 5, 0}, // less than one consumer still gets one
+	}
+	for _, tt := range tests {
+		name := fmt.Sprintf("%d items, buffer %d, %d consumers", tt.items, tt.bufSize, tt.consumers)
+		t.Run(name, func(t *testing.T) {
+			got := Run(context.Background(), tt.bufSize, tt.consumers, counter(tt.items))
+			if got != tt.items {
+				t.Errorf("processed %d, want %d", got, tt.items)
+			}
+		})
+	}
+}
+
+// Run with go test -race *.go.
+func TestRunCancelled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+
+	produced := 0
+	endless := func() (int, bool) {
+		produced++
+		return produced, true
+	}
+	processed := Run(ctx, 10, 2, endless)
+
+	// Only the item produced while the buffer was full can be lost.
+	if processed > produced || processed < produced-1 {
+		t.Errorf("produced %d, processed %d", produced, processed)
+	}
+	if processed == 0 {
+		t.Error("nothing was processed before the timeout")
+	}
+}
+
+func TestRunAlreadyCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	calls := 0
+	produce := func() (int, bool) { calls++; return calls, true }
+	if got := Run(ctx, 10, 2, produce); got != 0 || calls != 0 {
+		t.Errorf("processed %d after %d produce calls, want none", got, calls)
+	}
+}
+
+func TestCounter(t *testing.T) {
+	next := counter(3)
+	for want := 1; want <= 3; want++ {
+		if v, ok := next(); !ok || v != want {
+			t.Fatalf("got %d, %v, want %d", v, ok, want)
+		}
+	}
+	if _, ok := next(); ok {
+		t.Error("counter(3) gave a 4th item")
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// findUser pretends to look somebody up.
+func findUser(id int) Optional[string] {
+	if id == 1 {
+		return Some("Ada")
+	}
+	return None[string]()
+}
+
+func main() {
+	fmt.Println(findUser(1).OrElse("guest"), findUser(1).IsPresent())
+	fmt.Println(findUser(2).OrElse("guest"), findUser(2).IsPresent())
+
+	double := func(n int) int { return n * 2 }
+	for _, input := range []string{"21", "abc"} {
+		// Of turns the (int, error) of strconv.Atoi into a Result.
+		r := Map(Of(strconv.Atoi(input)), double)
+		if r.IsOk() {
+			fmt.Println(input, "doubled is", r.Unwrap())
+		} else {
+			fmt.Println(input, "failed:", r.Err(), "- using", r.OrElse(0))
+		}
+	}
+
+	// The same thing in idiomatic Go, for comparison.
+	n, err := strconv.Atoi("21")
+	if err != nil {
+		fmt.Println("failed:", err)
+		return
+	}
+	fmt.Println("idiomatic:", double(n))
+
+	// Unwrap on an error panics; recover here just to print the message.
+	defer func() { fmt.Println("recovered:", recover()) }()
+	Of(strconv.Atoi("abc")).Unwrap()
+}
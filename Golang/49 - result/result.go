@@ -0,0 +1,86 @@
+/*
+Optional and Result types, as found in languages like Rust or Swift.
+
+This is for learning only. Idiomatic Go returns (value, error) or
+(value, ok) and checks them right away with if; every Go programmer and
+every library expects that style, so prefer it in real code. These wrappers
+can still be handy when many steps are chained together, and they show
+what generics make possible.
+*/
+package main
+
+import "fmt"
+
+// Optional holds either a value (Some) or nothing (None).
+// The zero value is None.
+type Optional[T any] struct {
+	value   T
+	present bool
+}
+
+func Some[T any](v T) Optional[T] {
+	return Optional[T]{value: v, present: true}
+}
+
+func None[T any]() Optional[T] {
+	return Optional[T]{}
+}
+
+func (o Optional[T]) IsPresent() bool { return o.present }
+
+// OrElse returns the value, or def when there is none. It never panics.
+func (o Optional[T]) OrElse(def T) T {
+	if !o.present {
+		return def
+	}
+	return o.value
+}
+
+// Result holds either a value (Ok) or an error (Err).
+type Result[T any] struct {
+	value T
+	err   error
+}
+
+func Ok[T any](v T) Result[T] {
+	return Result[T]{value: v}
+}
+
+func Err[T any](err error) Result[T] {
+	return Result[T]{err: err}
+}
+
+// Of turns the usual (value, error) pair into a Result.
+func Of[T any](v T, err error) Result[T] {
+	return Result[T]{value: v, err: err}
+}
+
+func (r Result[T]) IsOk() bool { return r.err == nil }
+
+func (r Result[T]) Err() error { return r.err }
+
+// Unwrap returns the value, and panics if the Result holds an error.
+func (r Result[T]) Unwrap() T {
+	if r.err != nil {
+		panic(fmt.Sprintf("Unwrap called on an error Result: %v", r.err))
+	}
+	return r.value
+}
+
+// OrElse returns the value, or def when the Result holds an error.
+func (r Result[T]) OrElse(def T) T {
+	if r.err != nil {
+		return def
+	}
+	return r.value
+}
+
+// Map applies f to the value of an Ok result. An error is passed along
+// unchanged without calling f. It is a function, not a method, because
+// methods cannot have type parameters of their own (U here).
+func Map[T, U any](r Result[T], f func(T) U) Result[U] {
+	if r.err != nil {
+		return Err[U](r.err)
+	}
+	return Ok(f(r.value))
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestOptional(t *testing.T) {
+	tests := []struct {
+		name    string
+		o       Optional[string]
+		present bool
+		orElse  string
+	}{
+		{"some", Some("Ada"), true, "Ada"},
+		{"some empty string", Some(""), true, ""},
+		{"none", None[string](), false, "guest"},
+		{"zero value", Optional[string]{}, false, "guest"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.o.IsPresent(); got != tt.present {
+				t.Errorf("IsPresent = %v, want %v", got, tt.present)
+			}
+			if got := tt.o.OrElse("guest"); got != tt.orElse {
+				t.Errorf("OrElse = %q, want %q", got, tt.orElse)
+			}
+		})
+	}
+}
+
+func TestResult(t *testing.T) {
+	errBoom := errors.New("boom")
+	tests := []struct {
+		name   string
+		r      Result[int]
+		ok     bool
+		err    error
+		orElse int
+	}{
+		{"ok", Ok(42), true, nil, 42},
+		{"ok zero", Ok(0), true, nil, 0},
+		{"err", Err[int](errBoom), false, errBoom, -1},
+		{"of with value", Of(7, nil), true, nil, 7},
+		{"of with error", Of(7, errBoom), false, errBoom, -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.r.IsOk(); got != tt.ok {
+				t.Errorf("IsOk = %v, want %v", got, tt.ok)
+			}
+			if got := tt.r.Err(); got != tt.err {
+				t.Errorf("Err = %v, want %v", got, tt.err)
+			}
+			if got := tt.r.OrElse(-1); got != tt.orElse {
+				t.Errorf("OrElse = %d, want %d", got, tt.orElse)
+			}
+		})
+	}
+}
+
+func TestMap(t *testing.T) {
+	double := func(n int) int { return n * 2 }
+	tests := []struct {
+		input string
+		want  Result[string]
+	}{
+		{"21", Ok("42")},
+		{"-4", Ok("-8")},
+	}
+	for _, tt := range tests {
+		got := Map(Map(Of(strconv.Atoi(tt.input)), double), strconv.Itoa)
+		if got != tt.want {
+			t.Errorf("Map(%q) = %+v, want %+v", tt.input, got, tt.want)
+		}
+	}
+
+	called := false
+	r := Map(Of(strconv.Atoi("abc")), func(n int) int { called = true; return n })
+	if called {
+		t.Error("Map called f on an error Result")
+	}
+	if !errors.Is(r.Err(), strconv.ErrSyntax) {
+		t.Errorf("Err = %v, want the strconv error passed along", r.Err())
+	}
+}
+
+func TestUnwrap(t *testing.T) {
+	if got := Ok("x").Unwrap(); got != "x" {
+		t.Errorf("Unwrap = %q", got)
+	}
+
+	defer func() {
+		msg, _ := recover().(string)
+		if !strings.Contains(msg, "Unwrap called on an error Result: boom") {
+			t.Errorf("panic = %q", msg)
+		}
+	}()
+	Err[string](errors.New("boom")).Unwrap()
+	t.Error("Unwrap on an error did not panic")
+}
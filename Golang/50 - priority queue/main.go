@@ -0,0 +1,25 @@
+package main
+
+import "fmt"
+
+func main() {
+	var pq PriorityQueue
+	tasks := map[string]int{"write docs": 2, "fix bug": 5, "lunch": 3, "review PR": 4}
+	items := make(map[string]*Item)
+	for name, priority := range tasks {
+		items[name] = &Item{Value: name, Priority: priority}
+		pq.Push(items[name])
+	}
+
+	// Take the most urgent task, then the docs suddenly become urgent.
+	first, _ := pq.Pop()
+	fmt.Printf("%s (%d)\n", first.Value, first.Priority)
+	pq.Update(items["write docs"], 10)
+
+	for pq.Len() > 0 {
+		item, _ := pq.Pop()
+		fmt.Printf("%s (%d)\n", item.Value, item.Priority)
+	}
+	_, ok := pq.Pop()
+	fmt.Println("empty pop:", ok)
+}
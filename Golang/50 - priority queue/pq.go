@@ -0,0 +1,84 @@
+/*
+A priority queue always hands out the most important item first. It is built
+on container/heap, which keeps a slice arranged as a binary heap: the best
+item is at index 0, and Push, Pop and Fix only need O(log n) swaps.
+
+container/heap does not store anything itself. It works on any type with the
+five methods of heap.Interface (Len, Less, Swap, Push and Pop), here
+itemHeap. Those Push and Pop are only meant to be called by the heap
+package, so PriorityQueue wraps itemHeap and offers its own, safer, methods.
+*/
+package main
+
+import "container/heap"
+
+type Item struct {
+	Value    string
+	Priority int
+	index    int // position in the heap, kept up to date by Swap
+}
+
+// itemHeap implements heap.Interface.
+type itemHeap []*Item
+
+func (h itemHeap) Len() int { return len(h) }
+
+// Less says which item comes first. Using > instead of < makes it a max-heap:
+// the highest priority is at the top.
+func (h itemHeap) Less(i, j int) bool { return h[i].Priority > h[j].Priority }
+
+func (h itemHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+// Push and Pop only add and remove at the end of the slice, heap.Push and
+// heap.Pop do the rearranging.
+func (h *itemHeap) Push(x any) {
+	item := x.(*Item)
+	item.index = len(*h)
+	*h = append(*h, item)
+}
+
+func (h *itemHeap) Pop() any {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil  // let the garbage collector free it
+	item.index = -1 // no longer in the queue
+	*h = old[:n-1]
+	return item
+}
+
+// PriorityQueue hands out items highest priority first.
+// The zero value is an empty queue.
+type PriorityQueue struct {
+	items itemHeap
+}
+
+func (pq *PriorityQueue) Len() int { return pq.items.Len() }
+
+// Push adds item to the queue.
+func (pq *PriorityQueue) Push(item *Item) {
+	heap.Push(&pq.items, item)
+}
+
+// Pop removes and returns the item with the highest priority, or nil and
+// false if the queue is empty.
+func (pq *PriorityQueue) Pop() (*Item, bool) {
+	if pq.items.Len() == 0 {
+		return nil, false
+	}
+	return heap.Pop(&pq.items).(*Item), true
+}
+
+// Update changes the priority of an item already in the queue and moves it
+// to its new place. Items not in the queue are left alone.
+func (pq *PriorityQueue) Update(item *Item, priority int) {
+	if item.index < 0 || item.index >= pq.items.Len() || pq.items[item.index] != item {
+		return
+	}
+	item.Priority = priority
+	heap.Fix(&pq.items, item.index)
+}
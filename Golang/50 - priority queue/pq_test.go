@@ -0,0 +1,91 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+// popAll empties pq and returns the priorities in the order they came out.
+func popAll(pq *PriorityQueue) []int {
+	var got []int
+	for pq.Len() > 0 {
+		item, _ := pq.Pop()
+		got = append(got, item.Priority)
+	}
+	return got
+}
+
+func TestPriorityOrder(t *testing.T) {
+	tests := []struct {
+		name       string
+		priorities []int
+		want       []int
+	}{
+		{"empty", nil, nil},
+		{"one", []int{1}, []int{1}},
+		{"shuffled", []int{2, 5, 3, 4, 1}, []int{5, 4, 3, 2, 1}},
+		{"already sorted", []int{1, 2, 3}, []int{3, 2, 1}},
+		{"equal priorities", []int{2, 7, 2, 7}, []int{7, 7, 2, 2}},
+		{"negative", []int{-1, 0, -5}, []int{0, -1, -5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var pq PriorityQueue
+			for _, p := range tt.priorities {
+				pq.Push(&Item{Priority: p})
+			}
+			if got := popAll(&pq); !slices.Equal(got, tt.want) {
+				t.Errorf("popped %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdateMidStream(t *testing.T) {
+	var pq PriorityQueue
+	items := map[string]*Item{}
+	for name, p := range map[string]int{"write docs": 2, "fix bug": 5, "lunch": 3, "review PR": 4} {
+		items[name] = &Item{Value: name, Priority: p}
+		pq.Push(items[name])
+	}
+
+	first, _ := pq.Pop()
+	if first.Value != "fix bug" {
+		t.Fatalf("first = %q, want %q", first.Value, "fix bug")
+	}
+
+	pq.Update(items["write docs"], 10) // up to the top
+	pq.Update(items["review PR"], 1)   // down to the bottom
+	pq.Update(first, 100)              // already popped: ignored
+
+	var got []string
+	for pq.Len() > 0 {
+		item, _ := pq.Pop()
+		got = append(got, item.Value)
+	}
+	if want := []string{"write docs", "lunch", "review PR"}; !slices.Equal(got, want) {
+		t.Errorf("popped %q, want %q", got, want)
+	}
+	if first.Priority != 5 {
+		t.Errorf("Update changed a popped item to %d", first.Priority)
+	}
+}
+
+func TestUpdateForeignItem(t *testing.T) {
+	var a, b PriorityQueue
+	x := &Item{Value: "x", Priority: 1}
+	a.Push(x)
+	b.Push(&Item{Value: "y", Priority: 2})
+
+	b.Update(x, 50) // x lives in a, not b
+	if x.Priority != 1 {
+		t.Errorf("Update through the wrong queue changed x to %d", x.Priority)
+	}
+}
+
+func TestPopEmpty(t *testing.T) {
+	var pq PriorityQueue
+	if item, ok := pq.Pop(); ok || item != nil {
+		t.Errorf("Pop on an empty queue = %v, %v", item, ok)
+	}
+}
@@ -0,0 +1,103 @@
+/*
+text/template fills a text with data: {{.Name}} is replaced by the Name
+field of the data, {{range .Items}} repeats a block for every element, and
+{{.Price | currency}} passes a value through a function.
+
+Extra functions, like upper and currency here, are registered with a
+FuncMap before the template is parsed.
+
+A template can fail twice: when parsing (a typo like {{.Name) or when
+executing (a field that does not exist). Execute writes as it goes, so the
+output is built in a buffer and only returned if everything worked, instead
+of leaving half a report behind.
+*/
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+var funcs = template.FuncMap{
+	"upper":    strings.ToUpper,
+	"currency": currency,
+}
+
+// currency formats an amount with two decimals and thousands separators,
+// like $1,234.50.
+func currency(amount float64) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	s := strconv.FormatFloat(amount, 'f', 2, 64)
+	whole, cents, _ := strings.Cut(s, ".")
+
+	var sb strings.Builder
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteRune(digit)
+	}
+	return sign + "$" + sb.String() + "." + cents
+}
+
+// Render parses tmpl and executes it with data, returning the whole output
+// or an error, never a partial result.
+func Render(tmpl string, data any) (string, error) {
+	t, err := template.New("report").Funcs(funcs).Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("render: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("render: %w", err)
+	}
+	return buf.String(), nil
+}
+
+type Line struct {
+	Product string
+	Price   float64
+}
+
+type Invoice struct {
+	Customer string
+	Lines    []Line
+	Total    float64
+}
+
+const invoiceTemplate = `INVOICE FOR {{.Customer | upper}}
+{{range .Lines}}  {{printf "%-10s" .Product}} {{currency .Price}}
+{{end}}  Total:     {{currency .Total}}
+`
+
+func main() {
+	invoice := Invoice{
+		Customer: "Ada Lovelace",
+		Lines:    []Line{{"Laptop", 1299.99}, {"Mouse", 25.5}, {"Desk", 350}},
+		Total:    1675.49,
+	}
+	out, err := Render(invoiceTemplate, invoice)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	fmt.Print(out)
+
+	fmt.Println(currency(1234567.891), currency(0.5), currency(-42))
+
+	// Parse error: the action is never closed.
+	if _, err := Render("Hello {{.Customer", invoice); err != nil {
+		fmt.Println("Error:", err)
+	}
+	// Execution error: Invoice has no Email field.
+	if _, err := Render("Hello {{.Customer}}, we wrote to {{.Email}}", invoice); err != nil {
+		fmt.Println("Error:", err)
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderInvoice(t *testing.T) {
+	invoice := Invoice{
+		Customer: "Ada Lovelace",
+		Lines:    []Line{{"Laptop", 1299.99}, {"Mouse", 25.5}, {"Desk", 350}},
+		Total:    1675.49,
+	}
+	got, err := Render(invoiceTemplate, invoice)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `INVOICE FOR ADA LOVELACE
+  Laptop     $1,299.99
+  Mouse      $25.50
+  Desk       $350.00
+  Total:     $1,675.49
+`
+	if got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestRenderNoLines(t *testing.T) {
+	got, err := Render(invoiceTemplate, Invoice{Customer: "x"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "INVOICE FOR X\n  Total:     $0.00\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestRenderErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		tmpl    string
+		message string
+	}{
+		{"unclosed action", "Hello {{.Customer", "unclosed action"},
+		{"unknown function", "{{.Customer | shout}}", `function "shout" not defined`},
+		{"missing field", "Hello {{.Customer}}, we wrote to {{.Email}}", "can't evaluate field Email"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := Render(tt.tmpl, Invoice{Customer: "Ada"})
+			if err == nil || !strings.Contains(err.Error(), tt.message) {
+				t.Errorf("err = %v, want it to contain %q", err, tt.message)
+			}
+			// Execute already wrote "Hello Ada, we wrote to " before failing.
+			if out != "" {
+				t.Errorf("got partial output %q", out)
+			}
+		})
+	}
+}
+
+func TestCurrency(t *testing.T) {
+	tests := []struct {
+		in   float64
+		want string
+	}{
+		{0, "$0.00"},
+		{0.5, "$0.50"},
+		{999.999, "$1,000.00"},
+		{1000, "$1,000.00"},
+		{1234567.891, "$1,234,567.89"},
+		{100000, "$100,000.00"},
+		{-42, "-$42.00"},
+		{-1234.5, "-$1,234.50"},
+	}
+	for _, tt := range tests {
+		if got := currency(tt.in); got != tt.want {
+			t.Errorf("currency(%v) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Working with the single bits of an unsigned integer. Bit 0 is the rightmost
+(least significant) one, so 1<<pos is a number with only bit pos set:
+
+	SetBit    n | 1<<pos       turn the bit on
+	ClearBit  n &^ (1<<pos)    turn it off (&^ is "and not")
+	ToggleBit n ^ 1<<pos       flip it
+	HasBit    n&(1<<pos) != 0
+
+The parentheses in ClearBit matter: &^ and << have the same precedence, so
+n &^ 1<<pos would mean (n &^ 1) << pos.
+
+A uint has bits.UintSize bits, 64 on most computers. Every function here
+panics when pos, or the number of flags, does not fit in a uint: an
+out-of-range bit position is a bug in the caller, like an out-of-range
+slice index, and is reported the same way.
+*/
+package main
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+func checkPos(pos int) {
+	if pos < 0 || pos >= bits.UintSize {
+		panic(fmt.Sprintf("bit position %d out of range [0, %d)", pos, bits.UintSize))
+	}
+}
+
+func SetBit(n uint, pos int) uint {
+	checkPos(pos)
+	return n | 1<<pos
+}
+
+func ClearBit(n uint, pos int) uint {
+	checkPos(pos)
+	return n &^ (1 << pos)
+}
+
+func ToggleBit(n uint, pos int) uint {
+	checkPos(pos)
+	return n ^ 1<<pos
+}
+
+func HasBit(n uint, pos int) bool {
+	checkPos(pos)
+	return n&(1<<pos) != 0
+}
+
+// CountSetBits returns how many bits of n are 1. bits.OnesCount uses a
+// single processor instruction where there is one.
+func CountSetBits(n uint) int {
+	return bits.OnesCount(n)
+}
+
+// PackFlags stores flags[i] in bit i of the result. It panics if there
+// are more flags than bits in a uint.
+func PackFlags(flags []bool) uint {
+	if len(flags) > bits.UintSize {
+		panic(fmt.Sprintf("%d flags do not fit in a %d-bit uint", len(flags), bits.UintSize))
+	}
+	var n uint
+	for i, f := range flags {
+		if f {
+			n = SetBit(n, i)
+		}
+	}
+	return n
+}
+
+// UnpackFlags returns the lowest n bits of packed as booleans, the opposite
+// of PackFlags. It panics if n is negative or larger than a uint.
+func UnpackFlags(packed uint, n int) []bool {
+	if n < 0 || n > bits.UintSize {
+		panic(fmt.Sprintf("cannot unpack %d flags from a %d-bit uint", n, bits.UintSize))
+	}
+	flags := make([]bool, n)
+	for i := range flags {
+		flags[i] = HasBit(packed, i)
+	}
+	return flags
+}
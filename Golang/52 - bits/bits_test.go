@@ -0,0 +1,108 @@
+package main
+
+import (
+	"math/bits"
+	"slices"
+	"testing"
+)
+
+func TestBitOps(t *testing.T) {
+	tests := []struct {
+		n      uint
+		pos    int
+		set    uint
+		clear  uint
+		toggle uint
+		has    bool
+	}{
+		{0b1010, 0, 0b1011, 0b1010, 0b1011, false},
+		{0b1010, 1, 0b1010, 0b1000, 0b1000, true},
+		{0b1010, 3, 0b1010, 0b0010, 0b0010, true},
+		{0, 5, 0b100000, 0, 0b100000, false},
+		{1 << (bits.UintSize - 1), bits.UintSize - 1, 1 << (bits.UintSize - 1), 0, 0, true},
+	}
+	for _, tt := range tests {
+		if got := SetBit(tt.n, tt.pos); got != tt.set {
+			t.Errorf("SetBit(%b, %d) = %b, want %b", tt.n, tt.pos, got, tt.set)
+		}
+		if got := ClearBit(tt.n, tt.pos); got != tt.clear {
+			t.Errorf("ClearBit(%b, %d) = %b, want %b", tt.n, tt.pos, got, tt.clear)
+		}
+		if got := ToggleBit(tt.n, tt.pos); got != tt.toggle {
+			t.Errorf("ToggleBit(%b, %d) = %b, want %b", tt.n, tt.pos, got, tt.toggle)
+		}
+		if got := HasBit(tt.n, tt.pos); got != tt.has {
+			t.Errorf("HasBit(%b, %d) = %v, want %v", tt.n, tt.pos, got, tt.has)
+		}
+	}
+}
+
+func TestCountSetBits(t *testing.T) {
+	tests := []struct {
+		n    uint
+		want int
+	}{
+		{0, 0},
+		{1, 1},
+		{0b1011, 3},
+		{255, 8},
+		{^uint(0), bits.UintSize},
+	}
+	for _, tt := range tests {
+		if got := CountSetBits(tt.n); got != tt.want {
+			t.Errorf("CountSetBits(%b) = %d, want %d", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestPackFlags(t *testing.T) {
+	all := make([]bool, bits.UintSize)
+	for i := range all {
+		all[i] = true
+	}
+	tests := []struct {
+		flags []bool
+		want  uint
+	}{
+		{nil, 0},
+		{[]bool{true}, 1},
+		{[]bool{false, true}, 0b10},
+		{[]bool{true, false, true, true}, 0b1101},
+		{all, ^uint(0)},
+	}
+	for _, tt := range tests {
+		got := PackFlags(tt.flags)
+		if got != tt.want {
+			t.Errorf("PackFlags(%v) = %b, want %b", tt.flags, got, tt.want)
+		}
+		if back := UnpackFlags(got, len(tt.flags)); !slices.Equal(back, tt.flags) {
+			t.Errorf("UnpackFlags(%b, %d) = %v, want %v", got, len(tt.flags), back, tt.flags)
+		}
+	}
+}
+
+func TestOutOfRangePanics(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func()
+	}{
+		{"SetBit -1", func() { SetBit(0, -1) }},
+		{"SetBit UintSize", func() { SetBit(0, bits.UintSize) }},
+		{"ClearBit UintSize", func() { ClearBit(0, bits.UintSize) }},
+		{"ToggleBit -1", func() { ToggleBit(0, -1) }},
+		{"HasBit UintSize", func() { HasBit(0, bits.UintSize) }},
+		{"PackFlags too many", func() { PackFlags(make([]bool, bits.UintSize+1)) }},
+		{"UnpackFlags -1", func() { UnpackFlags(0, -1) }},
+		{"UnpackFlags too many", func() { UnpackFlags(0, bits.UintSize+1) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("did not panic")
+				}
+			}()
+			tt.fn()
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package main
+
+import "fmt"
+
+func main() {
+	var n uint = 0b1010
+	fmt.Printf("n           = %04b\n", n)
+	fmt.Printf("SetBit 0    = %04b\n", SetBit(n, 0))
+	fmt.Printf("ClearBit 1  = %04b\n", ClearBit(n, 1))
+	fmt.Printf("ToggleBit 3 = %04b\n", ToggleBit(n, 3))
+	fmt.Println("HasBit 1    =", HasBit(n, 1), " HasBit 2 =", HasBit(n, 2))
+	fmt.Println("set bits in 255:", CountSetBits(255))
+
+	// Eight yes/no settings stored in one number.
+	settings := []bool{true, false, true, true, false, false, false, true}
+	packed := PackFlags(settings)
+	fmt.Printf("packed %v into %08b\n", settings, packed)
+	fmt.Println("unpacked:", UnpackFlags(packed, len(settings)))
+
+	defer func() { fmt.Println("recovered:", recover()) }()
+	SetBit(n, 64)
+}
@@ -0,0 +1,83 @@
+/*
+Debouncing and throttling limit how often a function runs when it is called
+in bursts, like a search box that gets a call on every key press.
+
+  - Debounce waits until the calls stop: fn runs once, d after the last
+    call of a burst. Every new call pushes the deadline back.
+  - Throttle runs fn right away, then ignores calls until d has passed.
+    fn runs at most once per d, however often it is called.
+
+Both return two functions, like context.WithCancel: call, to use instead of
+fn, and stop, which releases the timer. After stop, call does nothing. Both
+are safe to use from several goroutines, since a mutex protects their state.
+*/
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// Debounce returns a call function that runs fn in its own goroutine once
+// calls have stopped for d. stop cancels a pending run; a run that already
+// started is not interrupted.
+func Debounce(d time.Duration, fn func()) (call, stop func()) {
+	var (
+		mu      sync.Mutex
+		timer   *time.Timer
+		stopped bool
+	)
+	call = func() {
+		mu.Lock()
+		defer mu.Unlock()
+		if stopped {
+			return
+		}
+		if timer == nil {
+			timer = time.AfterFunc(d, fn)
+			return
+		}
+		// Reset moves the deadline, also if fn already ran for an
+		// earlier burst.
+		timer.Reset(d)
+	}
+	stop = func() {
+		mu.Lock()
+		defer mu.Unlock()
+		stopped = true
+		if timer != nil {
+			timer.Stop()
+		}
+	}
+	return call, stop
+}
+
+// Throttle returns a call function that runs fn immediately, in the calling
+// goroutine, unless fn already ran less than d ago, in which case the call
+// is dropped. No timer is needed: comparing with the time of the last run is
+// enough. stop makes every later call a no-op.
+func Throttle(d time.Duration, fn func()) (call, stop func()) {
+	var (
+		mu      sync.Mutex
+		last    time.Time
+		stopped bool
+	)
+	call = func() {
+		mu.Lock()
+		if stopped || !last.IsZero() && time.Since(last) < d {
+			mu.Unlock()
+			return
+		}
+		last = time.Now()
+		mu.Unlock()
+		// fn runs without the lock held, so it may take long or even call
+		// call again without blocking other callers.
+		fn()
+	}
+	stop = func() {
+		mu.Lock()
+		defer mu.Unlock()
+		stopped = true
+	}
+	return call, stop
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// The delays are long compared to the calls, so a slow machine does not
+// split one burst into two.
+const (
+	debounceDelay = 50 * time.Millisecond
+	quiet         = 4 * debounceDelay // long enough for any pending run
+)
+
+// counting returns fn, which counts its runs and signals each one on ran.
+func counting() (fn func(), runs *atomic.Int64, ran chan struct{}) {
+	runs = new(atomic.Int64)
+	ran = make(chan struct{}, 10)
+	fn = func() {
+		runs.Add(1)
+		ran <- struct{}{}
+	}
+	return fn, runs, ran
+}
+
+func waitRun(t *testing.T, ran <-chan struct{}) {
+	t.Helper()
+	select {
+	case <-ran:
+	case <-time.After(5 * time.Second):
+		t.Fatal("fn never ran")
+	}
+}
+
+// hammer calls call from several goroutines at the same time.
+func hammer(call func()) {
+	var wg sync.WaitGroup
+	for g := 0; g < 5; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 20; i++ {
+				call()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestDebounceRunsOncePerBurst(t *testing.T) {
+	fn, runs, ran := counting()
+	call, stop := Debounce(debounceDelay, fn)
+	defer stop()
+
+	hammer(call)
+	if n := runs.Load(); n != 0 {
+		t.Errorf("%d runs right after the burst, want 0", n)
+	}
+	waitRun(t, ran)
+	time.Sleep(quiet)
+	if n := runs.Load(); n != 1 {
+		t.Fatalf("%d runs after one burst, want 1", n)
+	}
+
+	// A second burst, after fn already ran, fires again.
+	hammer(call)
+	waitRun(t, ran)
+	time.Sleep(quiet)
+	if n := runs.Load(); n != 2 {
+		t.Errorf("%d runs after two bursts, want 2", n)
+	}
+}
+
+func TestDebounceCallPushesDeadline(t *testing.T) {
+	fn, runs, ran := counting()
+	call, stop := Debounce(debounceDelay, fn)
+	defer stop()
+
+	start := time.Now()
+	call()
+	time.Sleep(debounceDelay / 2)
+	call()
+	waitRun(t, ran)
+	if elapsed := time.Since(start); elapsed < debounceDelay*3/2 {
+		t.Errorf("fn ran after %v, want at least %v", elapsed, debounceDelay*3/2)
+	}
+	if n := runs.Load(); n != 1 {
+		t.Errorf("%d runs, want 1", n)
+	}
+}
+
+func TestDebounceStop(t *testing.T) {
+	fn, runs, _ := counting()
+	call, stop := Debounce(debounceDelay, fn)
+
+	hammer(call)
+	stop()
+	call() // ignored after stop
+	time.Sleep(quiet)
+	if n := runs.Load(); n != 0 {
+		t.Errorf("%d runs after stop, want 0", n)
+	}
+}
+
+func TestThrottle(t *testing.T) {
+	fn, runs, _ := counting()
+	// An hour is never over during the test: only the first call runs.
+	call, stop := Throttle(time.Hour, fn)
+	defer stop()
+
+	hammer(call)
+	if n := runs.Load(); n != 1 {
+		t.Errorf("%d runs for 100 calls, want 1", n)
+	}
+}
+
+func TestThrottleAfterInterval(t *testing.T) {
+	const d = 20 * time.Millisecond
+	fn, runs, _ := counting()
+	call, stop := Throttle(d, fn)
+
+	call()
+	call() // dropped
+	time.Sleep(2 * d)
+	call()
+	if n := runs.Load(); n != 2 {
+		t.Errorf("%d runs, want 2", n)
+	}
+
+	stop()
+	time.Sleep(2 * d)
+	call()
+	if n := runs.Load(); n != 2 {
+		t.Errorf("%d runs after stop, want 2", n)
+	}
+}
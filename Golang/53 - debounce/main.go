@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// burst calls call from 5 goroutines, 20 times each, with a short pause
+// between calls, which takes about 20ms.
+func burst(call func()) {
+	var wg sync.WaitGroup
+	for g := 0; g < 5; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 20; i++ {
+				call()
+				time.Sleep(time.Millisecond)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func main() {
+	start := time.Now()
+	since := func() time.Duration { return time.Since(start).Round(10 * time.Millisecond) }
+
+	// 100 calls in a burst, but fn runs once, 30ms after the last call.
+	call, stop := Debounce(30*time.Millisecond, func() { fmt.Println("debounced run at", since()) })
+	fmt.Println("burst starts at", since())
+	burst(call)
+	fmt.Println("burst ends at", since())
+	time.Sleep(60 * time.Millisecond)
+
+	// A burst cut short by stop never fires.
+	burst(call)
+	stop()
+	time.Sleep(60 * time.Millisecond)
+	fmt.Println("stopped at", since(), "without another run")
+
+	// Throttle runs fn right away, then at most once every 10ms.
+	call, stop = Throttle(10*time.Millisecond, func() { fmt.Println("throttled run at", since()) })
+	burst(call)
+	stop()
+	call() // does nothing after stop
+}
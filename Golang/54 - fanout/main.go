@@ -0,0 +1,95 @@
+/*
+Fan-out, fan-in: spread the work over several goroutines (fan-out) and
+collect their results in one place (fan-in). Here the work is a fake image
+filter that is slow on every pixel, so more workers finish sooner.
+
+ParallelMap keeps the results in the same order as the input without any
+sorting: the workers receive indexes instead of items, and each one writes
+its result straight into out[i]. Every index is handled by exactly one
+worker, so no two goroutines ever write the same element and no mutex is
+needed; wg.Wait makes sure all writes are done before out is returned.
+
+main times the filter with different numbers of workers. main_test.go
+checks ParallelMap against Map and has a benchmark for the scaling:
+
+	go test -bench . *.go
+*/
+package main
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"slices"
+	"sync"
+	"time"
+)
+
+// ParallelMap returns f applied to every item, in input order, using up to
+// workers goroutines. There are never more workers than items, and at least
+// one.
+func ParallelMap[T, U any](items []T, workers int, f func(T) U) []U {
+	out := make([]U, len(items))
+	workers = min(max(workers, 1), len(items))
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				out[i] = f(items[i])
+			}
+		}()
+	}
+	for i := range items {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return out
+}
+
+// Map is the sequential version, to compare with.
+func Map[T, U any](items []T, f func(T) U) []U {
+	out := make([]U, len(items))
+	for i, item := range items {
+		out[i] = f(item)
+	}
+	return out
+}
+
+type Pixel struct{ R, G, B uint8 }
+
+// grayscale turns a pixel into its brightness. It computes the same value
+// 2000 times and averages it, only to be slow on purpose, like a real filter
+// that looks at the neighbouring pixels.
+func grayscale(p Pixel) uint8 {
+	brightness := 0.299*float64(p.R) + 0.587*float64(p.G) + 0.114*float64(p.B)
+	var sum float64
+	for i := 0; i < 2000; i++ {
+		sum += math.Sqrt(brightness * brightness)
+	}
+	return uint8(sum / 2000)
+}
+
+func image(n int) []Pixel {
+	pixels := make([]Pixel, n)
+	for i := range pixels {
+		pixels[i] = Pixel{uint8(i), uint8(i * 3), uint8(i * 7)}
+	}
+	return pixels
+}
+
+func main() {
+	pixels := image(1000)
+	want := Map(pixels, grayscale)
+	fmt.Printf("1000 pixels on %d CPUs:\n", runtime.NumCPU())
+	for _, workers := range []int{1, 2, 4, 8} {
+		start := time.Now()
+		got := ParallelMap(pixels, workers, grayscale)
+		elapsed := time.Since(start)
+		fmt.Printf("  %d workers: %10v, same as Map: %v\n", workers, elapsed, slices.Equal(got, want))
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestParallelMap(t *testing.T) {
+	for _, size := range []int{0, 1, 2, 3, 10, 100, 1000} {
+		items := make([]int, size)
+		for i := range items {
+			items[i] = i
+		}
+		square := func(v int) string { return strconv.Itoa(v * v) }
+		want := Map(items, square)
+		// Zero and negative workers mean one; more workers than items are
+		// limited to one per item.
+		for _, workers := range []int{-1, 0, 1, 3, 8, 2000} {
+			t.Run(fmt.Sprintf("size %d workers %d", size, workers), func(t *testing.T) {
+				if got := ParallelMap(items, workers, square); !slices.Equal(got, want) {
+					t.Errorf("got %v, want %v", got, want)
+				}
+			})
+		}
+	}
+}
+
+func TestParallelMapPixels(t *testing.T) {
+	pixels := image(300)
+	if got, want := ParallelMap(pixels, 4, grayscale), Map(pixels, grayscale); !slices.Equal(got, want) {
+		t.Error("ParallelMap(grayscale) differs from Map(grayscale)")
+	}
+}
+
+func BenchmarkWorkers(b *testing.B) {
+	pixels := image(1000)
+	for _, workers := range []int{1, 2, 4, 8, 16} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				ParallelMap(pixels, workers, grayscale)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Building and reading URLs with net/url instead of gluing strings together.
+
+Characters like spaces, & and = have a meaning in a URL, so values that
+contain them must be escaped: "rock & roll" becomes "rock+%26+roll".
+url.Values does this for us, and its Encode method writes the keys in
+sorted order, so the same parameters always give the same URL.
+
+A key can appear more than once in a query, as in ?tag=go&tag=web, which is
+why a parsed query maps every key to a slice of values.
+*/
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// BuildURL adds params to the query of base, which must be an absolute URL
+// like https://example.com/search. Parameters already in base are kept
+// unless params replaces them. The keys are sorted, so the result only
+// depends on the contents of params.
+func BuildURL(base string, params map[string]string) (string, error) {
+	u, err := url.Parse(base)
+	if err != nil {
+		return "", fmt.Errorf("build url: %w", err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return "", fmt.Errorf("build url: %q is not an absolute URL", base)
+	}
+	query := u.Query()
+	for k, v := range params {
+		query.Set(k, v)
+	}
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// ParseQuery decodes a query string like "a=1&b=2&a=3", with or without the
+// leading "?", into a map from every key to all of its values.
+func ParseQuery(raw string) (map[string][]string, error) {
+	values, err := url.ParseQuery(strings.TrimPrefix(raw, "?"))
+	if err != nil {
+		return nil, fmt.Errorf("parse query: %w", err)
+	}
+	return values, nil
+}
+
+func main() {
+	params := map[string]string{
+		"q":    "rock & roll",
+		"page": "2",
+		"lang": "pt-BR",
+		"path": "a/b?c=d",
+	}
+	u, err := BuildURL("https://example.com/search?safe=on", params)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	fmt.Println(u)
+
+	// Parsing undoes the escaping.
+	query, err := ParseQuery("?tag=go&tag=web&name=Jo%C3%A3o+Silva&empty=")
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	fmt.Printf("tag=%q name=%q empty=%q\n", query["tag"], query["name"], query["empty"])
+
+	for _, base := range []string{"http://host:port/x", "not a url", "/relative/path"} {
+		if _, err := BuildURL(base, params); err != nil {
+			fmt.Println("Error:", err)
+		}
+	}
+	if _, err := ParseQuery("a=%zz"); err != nil {
+		fmt.Println("Error:", err)
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBuildURL(t *testing.T) {
+	tests := []struct {
+		name   string
+		base   string
+		params map[string]string
+		want   string
+	}{
+		{"no params", "https://example.com/search", nil, "https://example.com/search"},
+		{"escaping", "https://example.com/search", map[string]string{"q": "rock & roll", "path": "a/b?c=d"},
+			"https://example.com/search?path=a%2Fb%3Fc%3Dd&q=rock+%26+roll"},
+		{"unicode", "https://example.com/", map[string]string{"name": "João"}, "https://example.com/?name=Jo%C3%A3o"},
+		{"keeps existing", "https://example.com/search?safe=on", map[string]string{"page": "2"},
+			"https://example.com/search?page=2&safe=on"},
+		{"replaces existing", "https://example.com/search?page=1&page=9", map[string]string{"page": "2"},
+			"https://example.com/search?page=2"},
+		{"sorted keys", "http://localhost:8080", map[string]string{"b": "2", "c": "3", "a": "1"},
+			"http://localhost:8080?a=1&b=2&c=3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BuildURL(tt.base, tt.params)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("BuildURL = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildURLInvalidBase(t *testing.T) {
+	// A base that url.Parse rejects: the *url.Error is wrapped.
+	_, err := BuildURL("http://host:port/x", nil)
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		t.Errorf("err = %v, want a wrapped *url.Error", err)
+	}
+	if err != nil && !strings.HasPrefix(err.Error(), "build url: ") {
+		t.Errorf("err = %q, want it to start with %q", err, "build url: ")
+	}
+
+	// Bases that parse but are not absolute.
+	for _, base := range []string{"not a url", "/relative/path", "example.com/x"} {
+		if _, err := BuildURL(base, nil); err == nil || !strings.Contains(err.Error(), "is not an absolute URL") {
+			t.Errorf("BuildURL(%q) err = %v", base, err)
+		}
+	}
+}
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want map[string][]string
+	}{
+		{"", map[string][]string{}},
+		{"?tag=go&tag=web", map[string][]string{"tag": {"go", "web"}}},
+		{"name=Jo%C3%A3o+Silva&empty=", map[string][]string{"name": {"João Silva"}, "empty": {""}}},
+		{"q=rock+%26+roll&flag", map[string][]string{"q": {"rock & roll"}, "flag": {""}}},
+	}
+	for _, tt := range tests {
+		got, err := ParseQuery(tt.raw)
+		if err != nil {
+			t.Fatalf("ParseQuery(%q): %v", tt.raw, err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseQuery(%q) = %v, want %v", tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestParseQueryInvalidEscape(t *testing.T) {
+	_, err := ParseQuery("a=%zz")
+	var escapeErr url.EscapeError
+	if !errors.As(err, &escapeErr) {
+		t.Errorf("err = %v, want a wrapped url.EscapeError", err)
+	}
+}
+
+// What BuildURL writes, ParseQuery reads back.
+func TestBuildParseRoundTrip(t *testing.T) {
+	params := map[string]string{"q": "rock & roll", "path": "a/b?c=d", "lang": "pt-BR"}
+	u, err := BuildURL("https://example.com/search", params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, raw, _ := strings.Cut(u, "?")
+	got, err := ParseQuery(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range params {
+		if !reflect.DeepEqual(got[k], []string{v}) {
+			t.Errorf("%s = %q, want [%q]", k, got[k], v)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+/*
+A circuit breaker stops calling a service that keeps failing, like the fuse
+in a house: instead of waiting for one more timeout, callers get an error
+straight away and the service gets time to recover.
+
+The breaker is in one of three states:
+
+  - Closed: calls go through. After threshold failures in a row it opens.
+  - Open: every call fails at once with ErrCircuitOpen, until the cooldown
+    has passed.
+  - HalfOpen: a single call, the probe, is let through to test the service.
+    If it works the breaker closes again, if it fails it opens for another
+    cooldown. Other calls made while the probe runs are rejected.
+
+A mutex protects the state, but it is not held while fn runs, so a slow fn
+does not block the callers that are only going to be rejected.
+*/
+package main
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned by Do, without calling fn, while the breaker is
+// open or a half-open probe is already running.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+type State int
+
+const (
+	Closed State = iota
+	Open
+	HalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// Breaker is safe to use from several goroutines. Create it with NewBreaker.
+type Breaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	state    State
+	failures int       // consecutive failures while closed
+	openedAt time.Time // when the breaker last opened
+	probing  bool      // a half-open probe is running
+}
+
+// NewBreaker returns a closed breaker that opens after threshold consecutive
+// failures (at least 1) and stays open for cooldown.
+func NewBreaker(threshold int, cooldown time.Duration) *Breaker {
+	return &Breaker{threshold: max(threshold, 1), cooldown: cooldown}
+}
+
+// State returns the current state. An open breaker whose cooldown has passed
+// reports HalfOpen, since the next call would be the probe.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == Open && time.Since(b.openedAt) >= b.cooldown {
+		return HalfOpen
+	}
+	return b.state
+}
+
+// Do calls fn unless the breaker is open, and returns its error.
+func (b *Breaker) Do(fn func() error) error {
+	probe, err := b.before()
+	if err != nil {
+		return err
+	}
+	err = fn()
+	b.after(probe, err)
+	return err
+}
+
+// before decides whether a call may go through, and whether it is the probe.
+func (b *Breaker) before() (probe bool, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == Open {
+		if time.Since(b.openedAt) < b.cooldown {
+			return false, ErrCircuitOpen
+		}
+		b.state = HalfOpen
+	}
+	if b.state == HalfOpen {
+		if b.probing {
+			return false, ErrCircuitOpen
+		}
+		b.probing = true
+		return true, nil
+	}
+	return false, nil
+}
+
+// after records the result of a call that went through.
+func (b *Breaker) after(probe bool, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch {
+	case probe:
+		b.probing = false
+		if err != nil {
+			b.trip()
+		} else {
+			b.state = Closed
+			b.failures = 0
+		}
+	case b.state != Closed:
+		// The breaker opened while this call was running, because of
+		// other calls failing; its result no longer matters.
+	case err != nil:
+		b.failures++
+		if b.failures >= b.threshold {
+			b.trip()
+		}
+	default:
+		b.failures = 0
+	}
+}
+
+// trip opens the breaker. b.mu must be held.
+func (b *Breaker) trip() {
+	b.state = Open
+	b.openedAt = time.Now()
+	b.failures = 0
+}
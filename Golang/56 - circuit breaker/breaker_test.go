@@ -0,0 +1,112 @@
+package main
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var errFail = errors.New("fail")
+
+func fail() error    { return errFail }
+func succeed() error { return nil }
+
+func TestBreakerTransitions(t *testing.T) {
+	const cooldown = 20 * time.Millisecond
+	// A step calls Do with fn (or only waits, when fn is nil) and checks
+	// the error and the state afterwards.
+	type step struct {
+		fn      func() error
+		wait    time.Duration
+		wantErr error
+		want    State
+	}
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{"opens after threshold failures", []step{
+			{fn: fail, wantErr: errFail, want: Closed},
+			{fn: fail, wantErr: errFail, want: Closed},
+			{fn: fail, wantErr: errFail, want: Open},
+			{fn: succeed, wantErr: ErrCircuitOpen, want: Open},
+		}},
+		{"a success resets the count", []step{
+			{fn: fail, wantErr: errFail, want: Closed},
+			{fn: fail, wantErr: errFail, want: Closed},
+			{fn: succeed, wantErr: nil, want: Closed},
+			{fn: fail, wantErr: errFail, want: Closed},
+			{fn: fail, wantErr: errFail, want: Closed},
+		}},
+		{"closed, open, half-open, closed", []step{
+			{fn: fail, wantErr: errFail, want: Closed},
+			{fn: fail, wantErr: errFail, want: Closed},
+			{fn: fail, wantErr: errFail, want: Open},
+			{wait: cooldown * 2, want: HalfOpen},
+			{fn: succeed, wantErr: nil, want: Closed},
+			{fn: fail, wantErr: errFail, want: Closed},
+		}},
+		{"failed probe opens again", []step{
+			{fn: fail, wantErr: errFail, want: Closed},
+			{fn: fail, wantErr: errFail, want: Closed},
+			{fn: fail, wantErr: errFail, want: Open},
+			{wait: cooldown * 2, want: HalfOpen},
+			{fn: fail, wantErr: errFail, want: Open},
+			{fn: succeed, wantErr: ErrCircuitOpen, want: Open},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBreaker(3, cooldown)
+			for i, s := range tt.steps {
+				if s.fn == nil {
+					time.Sleep(s.wait)
+				} else if err := b.Do(s.fn); !errors.Is(err, s.wantErr) {
+					t.Fatalf("step %d: Do = %v, want %v", i, err, s.wantErr)
+				}
+				if got := b.State(); got != s.want {
+					t.Fatalf("step %d: state %v, want %v", i, got, s.want)
+				}
+			}
+		})
+	}
+}
+
+// Of many concurrent calls in half-open state, only one reaches fn.
+func TestBreakerSingleProbe(t *testing.T) {
+	b := NewBreaker(1, 10*time.Millisecond)
+	b.Do(fail)
+	time.Sleep(20 * time.Millisecond)
+
+	var calls, rejected atomic.Int64
+	release := make(chan struct{})
+	probe := func() error {
+		calls.Add(1)
+		<-release // hold the probe until every other call was made
+		return nil
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if errors.Is(b.Do(probe), ErrCircuitOpen) {
+				rejected.Add(1)
+			}
+		}()
+	}
+	for calls.Load()+rejected.Load() < 20 {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+
+	if calls.Load() != 1 || rejected.Load() != 19 {
+		t.Errorf("%d calls reached fn and %d were rejected, want 1 and 19", calls.Load(), rejected.Load())
+	}
+	if b.State() != Closed {
+		t.Errorf("state %v after a good probe, want closed", b.State())
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// service stands in for a remote call that fails while down is true, and
+// counts how often it was really called.
+type service struct {
+	down  atomic.Bool
+	calls atomic.Int64
+}
+
+var errUnavailable = errors.New("service unavailable")
+
+func (s *service) call() error {
+	s.calls.Add(1)
+	time.Sleep(5 * time.Millisecond)
+	if s.down.Load() {
+		return errUnavailable
+	}
+	return nil
+}
+
+func main() {
+	svc := &service{}
+	b := NewBreaker(3, 50*time.Millisecond)
+
+	svc.down.Store(true)
+	for i := 1; i <= 5; i++ {
+		err := b.Do(svc.call)
+		fmt.Printf("call %d: %v (breaker %v)\n", i, err, b.State())
+	}
+	fmt.Println("calls that reached the service:", svc.calls.Load())
+
+	// The cooldown passes while the service is still down: the probe fails
+	// and the breaker opens again.
+	time.Sleep(60 * time.Millisecond)
+	fmt.Println("after cooldown:", b.State())
+	fmt.Println("probe:", b.Do(svc.call), "- breaker", b.State())
+
+	// Now the service is back. Of 10 concurrent calls only the probe gets
+	// through; once it succeeds the breaker closes.
+	svc.down.Store(false)
+	time.Sleep(60 * time.Millisecond)
+	before := svc.calls.Load()
+	var wg sync.WaitGroup
+	var rejected atomic.Int64
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if errors.Is(b.Do(svc.call), ErrCircuitOpen) {
+				rejected.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	fmt.Printf("10 concurrent calls: %d reached the service, %d rejected, breaker %v\n",
+		svc.calls.Load()-before, rejected.Load(), b.State())
+	fmt.Println("next call:", b.Do(svc.call))
+}
@@ -0,0 +1,153 @@
+/*
+NDJSON (newline-delimited JSON, also called JSON Lines) stores one JSON
+object per line. Log files and data exports often use it, because a program
+can append a record without rewriting the file and read records one at a
+time, without loading the whole file.
+
+WriteRecords uses a json.Encoder, which already ends every value with a
+newline. ReadRecords reads the stream with a json.Decoder, one value at a
+time. A Decoder has no line length limit, unlike bufio.Scanner, but it does
+not know about lines either: it would happily accept an object spread over
+several lines, or two objects on the same line, neither of which is valid
+NDJSON. So the input goes through a lineCounter, which remembers where the
+newlines are, and ReadRecords uses dec.InputOffset() to find the line of
+every record and to report it in errors.
+*/
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type Record struct {
+	ID   int      `json:"id"`
+	Name string   `json:"name"`
+	Tags []string `json:"tags,omitempty"`
+}
+
+// WriteRecords writes every record to w as one line of JSON.
+func WriteRecords(w io.Writer, recs []Record) error {
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	for i, rec := range recs {
+		if err := enc.Encode(rec); err != nil {
+			return fmt.Errorf("write record %d: %w", i+1, err)
+		}
+	}
+	return bw.Flush()
+}
+
+// lineCounter passes reads through and remembers where the newlines are, so
+// an offset in the stream can be turned into a line number.
+type lineCounter struct {
+	r        io.Reader
+	read     int64   // bytes read so far
+	newlines []int64 // offsets of the newlines lineAt has not passed yet
+	line     int     // line of the last offset given to lineAt
+}
+
+func (lc *lineCounter) Read(p []byte) (int, error) {
+	n, err := lc.r.Read(p)
+	for i, b := range p[:n] {
+		if b == '\n' {
+			lc.newlines = append(lc.newlines, lc.read+int64(i))
+		}
+	}
+	lc.read += int64(n)
+	return n, err
+}
+
+// lineAt returns the line, counting from 1, of the byte at offset off.
+// Offsets must not go backwards from one call to the next, which lets it
+// forget the newlines it has passed.
+func (lc *lineCounter) lineAt(off int64) int {
+	for len(lc.newlines) > 0 && lc.newlines[0] < off {
+		lc.line++
+		lc.newlines = lc.newlines[1:]
+	}
+	return lc.line + 1
+}
+
+// ReadRecords decodes one record per line of r, skipping blank lines. Lines
+// can be of any length, and only one record is held in memory at a time,
+// besides the records already read.
+func ReadRecords(r io.Reader) ([]Record, error) {
+	lc := &lineCounter{r: r}
+	dec := json.NewDecoder(lc)
+	var recs []Record
+	prevLine := 0
+	// More skips the whitespace, blank lines included, before the next value,
+	// so InputOffset is then where that value starts.
+	for dec.More() {
+		line := lc.lineAt(dec.InputOffset())
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if line == prevLine {
+			return nil, fmt.Errorf("line %d: more than one record on the line", line)
+		}
+		// Newlines inside JSON strings are escaped, so a raw one means the
+		// value goes on over the next line.
+		if bytes.IndexByte(raw, '\n') >= 0 {
+			return nil, fmt.Errorf("line %d: record continues on the next line", line)
+		}
+		var rec Record
+		if err := json.Unmarshal(raw, &rec); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		recs = append(recs, rec)
+		prevLine = line
+	}
+	// More also stops at a stray ] or }, and on read errors. Decode tells
+	// those apart from the end of the input.
+	var extra json.RawMessage
+	if err := dec.Decode(&extra); err != io.EOF {
+		line := lc.lineAt(dec.InputOffset())
+		if err == nil {
+			err = fmt.Errorf("unexpected %s", extra)
+		}
+		return nil, fmt.Errorf("line %d: %w", line, err)
+	}
+	return recs, nil
+}
+
+func main() {
+	recs := []Record{
+		{ID: 1, Name: "Ada", Tags: []string{"math", "engines"}},
+		{ID: 2, Name: "Grace"},
+		{ID: 3, Name: "Line\nbreaks are escaped", Tags: []string{"x"}},
+	}
+	var buf bytes.Buffer
+	if err := WriteRecords(&buf, recs); err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	fmt.Print(buf.String())
+
+	back, err := ReadRecords(&buf)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	for _, rec := range back {
+		fmt.Printf("read back: id=%d name=%q tags=%q\n", rec.ID, rec.Name, rec.Tags)
+	}
+
+	input := `{"id": 1, "name": "ok"}
+
+{"id": 2, "name": "also ok"}
+{"id": "three", "name": "wrong type"}
+`
+	if _, err := ReadRecords(strings.NewReader(input)); err != nil {
+		fmt.Println("Error:", err)
+	}
+	if _, err := ReadRecords(strings.NewReader("{\"id\": 1,\n\"name\": \"split\"}\n")); err != nil {
+		fmt.Println("Error:", err)
+	}
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		recs []Record
+	}{
+		{"none", nil},
+		{"one", []Record{{ID: 1, Name: "Ada"}}},
+		{"several", []Record{
+			{ID: 1, Name: "Ada", Tags: []string{"math"}},
+			{ID: 2, Name: "Grace"},
+			{ID: 3, Name: "new\nline and \"quotes\"", Tags: []string{"a", "b"}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WriteRecords(&buf, tt.recs); err != nil {
+				t.Fatal(err)
+			}
+			if lines := strings.Count(buf.String(), "\n"); lines != len(tt.recs) {
+				t.Errorf("wrote %d lines for %d records", lines, len(tt.recs))
+			}
+			got, err := ReadRecords(&buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.recs) {
+				t.Errorf("read back %+v, want %+v", got, tt.recs)
+			}
+		})
+	}
+}
+
+func TestReadRecords(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    int    // records read
+		wantErr string // part of the error, "" for none
+	}{
+		{"empty input", "", 0, ""},
+		{"blank lines skipped", "\n  \n{\"id\":1}\n\n{\"id\":2}\n\n", 2, ""},
+		{"no final newline", "{\"id\":1}\n{\"id\":2}", 2, ""},
+		{"windows line ends", "{\"id\":1}\r\n{\"id\":2}\r\n", 2, ""},
+		{"malformed line", "{\"id\":1}\n\n{\"id\":\n", 0, "line 3:"},
+		{"wrong type", "{\"id\":1}\n{\"id\":\"two\"}\n", 0, "line 2:"},
+		{"split object", "{\"id\": 1,\n\"name\": \"x\"}\n", 0, "line 1:"},
+		{"two objects on a line", "{\"id\":1} {\"id\":2}\n", 0, "line 1:"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadRecords(strings.NewReader(tt.input))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != tt.want {
+				t.Errorf("read %d records, want %d", len(got), tt.want)
+			}
+		})
+	}
+}
+
+// longLine returns a record line of exactly n bytes, newline included.
+func longLine(n int) string {
+	prefix, suffix := `{"id":1,"name":"`, "\"}\n"
+	return prefix + strings.Repeat("x", n-len(prefix)-len(suffix)) + suffix
+}
+
+// A Decoder has no line length limit, and the line numbers after a long line
+// are still right.
+func TestLongLines(t *testing.T) {
+	input := longLine(4*1024*1024) + "\n" + longLine(100*1024) + "{\"id\":\"bad\"}\n"
+	_, err := ReadRecords(strings.NewReader(input))
+	if err == nil || !strings.Contains(err.Error(), "line 4:") {
+		t.Errorf("err = %v, want an error on line 4", err)
+	}
+
+	recs, err := ReadRecords(strings.NewReader(longLine(4 * 1024 * 1024)))
+	if err != nil || len(recs) != 1 || len(recs[0].Name) < 4_000_000 {
+		t.Errorf("4 MB line: %d records, err = %v", len(recs), err)
+	}
+}
+
+func TestReadRecordsLineNumbers(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"after blank lines", "\n\n{\"id\":1}\n\n\n{\"id\":[]}\n", "line 6:"},
+		{"stray bracket", "{\"id\":1}\n]\n", "line 2: invalid character ']'"},
+		{"stray brace after blank line", "{\"id\":1}\n\n}", "line 3: invalid character '}'"},
+		{"truncated last line", "{\"id\":1}\n{\"id\":2", "line 2: unexpected EOF"},
+		{"three on a line", "{\"id\":1}\n{\"id\":2}{\"id\":3}\n", "line 2: more than one record"},
+		{"not an object", "{\"id\":1}\n42\n", "line 2: json: cannot unmarshal number"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadRecords(strings.NewReader(tt.input))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadRecordsReadError(t *testing.T) {
+	boom := errors.New("disk on fire")
+	r := io.MultiReader(strings.NewReader("{\"id\":1}\n{\"id\":2}\n"), iotest.ErrReader(boom))
+	_, err := ReadRecords(r)
+	if !errors.Is(err, boom) {
+		t.Errorf("err = %v, want %v", err, boom)
+	}
+}
+
+// iotest.OneByteReader makes the decoder see the input a byte at a time, so
+// the line counter is called with offsets in every possible position.
+func TestReadRecordsOneByteAtATime(t *testing.T) {
+	input := "{\"id\":1}\n\n{\"id\":2,\n\"name\":\"x\"}\n"
+	_, err := ReadRecords(iotest.OneByteReader(strings.NewReader(input)))
+	if err == nil || !strings.Contains(err.Error(), "line 3: record continues") {
+		t.Errorf("err = %v, want a split record on line 3", err)
+	}
+	recs, err := ReadRecords(iotest.OneByteReader(strings.NewReader("{\"id\":1}\n{\"id\":2}\n")))
+	if err != nil || len(recs) != 2 {
+		t.Errorf("%d records, err = %v", len(recs), err)
+	}
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+)
+
+func main() {
+	words := []string{"apple", "app", "ape", "apply", "banana", "band", "bandana",
+		"ação", "acção", "açúcar", "日本", "日本語", "app"}
+	t := NewTrie()
+	for _, w := range words {
+		t.Insert(w)
+	}
+	fmt.Println("words stored:", t.Len())
+
+	for _, w := range []string{"app", "appl", "apple", "", "日本", "日"} {
+		fmt.Printf("Contains(%q) = %v\n", w, t.Contains(w))
+	}
+	for _, p := range []string{"app", "band", "aç", "日", "xyz"} {
+		fmt.Printf("WithPrefix(%q) = %q\n", p, t.WithPrefix(p))
+	}
+
+	// The empty string is a word like any other.
+	t.Insert("")
+	fmt.Printf("after Insert(\"\"): Contains(\"\") = %v\n", t.Contains(""))
+
+	// WithPrefix("") gives every word, in the same order as sort.Strings.
+	all := t.WithPrefix("")
+	fmt.Println("all words sorted:", sort.StringsAreSorted(all), len(all))
+	fmt.Println("same as sorting:", slices.Equal(all, sorted(words)))
+}
+
+// sorted returns the distinct words plus the empty string, sorted.
+func sorted(words []string) []string {
+	out := append([]string{""}, words...)
+	sort.Strings(out)
+	return slices.Compact(out)
+}
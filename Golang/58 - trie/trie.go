@@ -0,0 +1,105 @@
+/*
+A trie (from "retrieval", usually said "try") stores words as a tree of
+letters. Words that start the same share the same path from the root:
+
+	root ─ a ─ p ─ p•─ l ─ e•
+	           └ e•
+
+holds "app", "apple" and "ape". The dots mark nodes where a word ends,
+which is how "app" can be stored and looked up although the path goes on to
+"apple". Finding every word with a given prefix means walking down the
+prefix and collecting everything below it.
+
+Words are split into runes, not bytes, so "ção" takes three steps and not
+five. The empty string is a word too: it ends at the root.
+*/
+package main
+
+import "slices"
+
+type node struct {
+	children map[rune]*node
+	end      bool // a word ends here
+}
+
+// Trie is a set of words. Create it with NewTrie.
+type Trie struct {
+	root *node
+	size int
+}
+
+func NewTrie() *Trie {
+	return &Trie{root: &node{}}
+}
+
+// Insert adds word; inserting a word twice has no effect.
+func (t *Trie) Insert(word string) {
+	n := t.root
+	for _, r := range word {
+		child, ok := n.children[r]
+		if !ok {
+			if n.children == nil {
+				n.children = make(map[rune]*node)
+			}
+			child = &node{}
+			n.children[r] = child
+		}
+		n = child
+	}
+	if !n.end {
+		n.end = true
+		t.size++
+	}
+}
+
+// Len returns the number of distinct words.
+func (t *Trie) Len() int { return t.size }
+
+// find returns the node at the end of the path for s, or nil if there is
+// none.
+func (t *Trie) find(s string) *node {
+	n := t.root
+	for _, r := range s {
+		n = n.children[r]
+		if n == nil {
+			return nil
+		}
+	}
+	return n
+}
+
+// Contains reports whether word itself was inserted. A prefix of a word,
+// like "app" for "apple", only counts if it was inserted too.
+func (t *Trie) Contains(word string) bool {
+	n := t.find(word)
+	return n != nil && n.end
+}
+
+// WithPrefix returns the words starting with prefix, sorted, or an empty
+// slice if there are none. WithPrefix("") returns every word.
+func (t *Trie) WithPrefix(prefix string) []string {
+	words := []string{}
+	n := t.find(prefix)
+	if n == nil {
+		return words
+	}
+	return collect(n, []rune(prefix), words)
+}
+
+// collect appends the words below n, each starting with path, to words.
+// Children are visited in rune order, and UTF-8 keeps that order when
+// comparing strings, so the words come out sorted.
+func collect(n *node, path []rune, words []string) []string {
+	if n.end {
+		words = append(words, string(path))
+	}
+	keys := make([]rune, 0, len(n.children))
+	for r := range n.children {
+		keys = append(keys, r)
+	}
+	slices.Sort(keys)
+	for _, r := range keys {
+		words = collect(n.children[r], append(path, r), words)
+	}
+	return words
+}
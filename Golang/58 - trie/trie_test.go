@@ -0,0 +1,99 @@
+package main
+
+import (
+	"slices"
+	"sort"
+	"testing"
+)
+
+var words = []string{"apple", "app", "ape", "apply", "banana", "band", "bandana",
+	"ação", "acção", "açúcar", "日本", "日本語", "app"}
+
+func newTestTrie() *Trie {
+	t := NewTrie()
+	for _, w := range words {
+		t.Insert(w)
+	}
+	return t
+}
+
+func TestWithPrefix(t *testing.T) {
+	trie := newTestTrie()
+	tests := []struct {
+		prefix string
+		want   []string
+	}{
+		{"app", []string{"app", "apple", "apply"}},
+		{"ap", []string{"ape", "app", "apple", "apply"}},
+		{"apple", []string{"apple"}},
+		{"band", []string{"band", "bandana"}},
+		{"aç", []string{"ação", "açúcar"}},
+		{"日", []string{"日本", "日本語"}},
+		{"b", []string{"banana", "band", "bandana"}},
+	}
+	for _, tt := range tests {
+		if got := trie.WithPrefix(tt.prefix); !slices.Equal(got, tt.want) {
+			t.Errorf("WithPrefix(%q) = %q, want %q", tt.prefix, got, tt.want)
+		}
+	}
+}
+
+func TestWithPrefixNoMatch(t *testing.T) {
+	trie := newTestTrie()
+	for _, prefix := range []string{"xyz", "applesauce", "bandanas", "日本語です", "A"} {
+		got := trie.WithPrefix(prefix)
+		if got == nil || len(got) != 0 {
+			t.Errorf("WithPrefix(%q) = %#v, want an empty, non-nil slice", prefix, got)
+		}
+	}
+	if got := NewTrie().WithPrefix(""); got == nil || len(got) != 0 {
+		t.Errorf("empty trie: WithPrefix(\"\") = %#v", got)
+	}
+}
+
+func TestWithPrefixAllSorted(t *testing.T) {
+	trie := newTestTrie()
+	all := trie.WithPrefix("")
+	want := slices.Clone(words)
+	sort.Strings(want)
+	want = slices.Compact(want)
+	if !slices.Equal(all, want) {
+		t.Errorf("WithPrefix(\"\") = %q, want %q", all, want)
+	}
+}
+
+func TestContains(t *testing.T) {
+	trie := newTestTrie()
+	tests := []struct {
+		word string
+		want bool
+	}{
+		{"app", true},
+		{"appl", false}, // only a prefix
+		{"apple", true},
+		{"", false},
+		{"日本", true},
+		{"日", false},
+		{"acção", true},
+	}
+	for _, tt := range tests {
+		if got := trie.Contains(tt.word); got != tt.want {
+			t.Errorf("Contains(%q) = %v, want %v", tt.word, got, tt.want)
+		}
+	}
+}
+
+func TestInsertLen(t *testing.T) {
+	trie := newTestTrie()
+	if trie.Len() != 12 { // "app" is in the list twice
+		t.Errorf("Len = %d, want 12", trie.Len())
+	}
+	trie.Insert("")
+	trie.Insert("")
+	if !trie.Contains("") || trie.Len() != 13 {
+		t.Errorf("after Insert(\"\"): Contains = %v, Len = %d", trie.Contains(""), trie.Len())
+	}
+	if got := trie.WithPrefix(""); got[0] != "" {
+		t.Errorf("the empty string should sort first, got %q", got[0])
+	}
+}
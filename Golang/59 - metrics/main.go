@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+func main() {
+	var m Metrics
+	const goroutines, perGoroutine = 100, 1000
+
+	// Every worker adds to "requests" before "bytes", so a consistent
+	// snapshot never shows more than 512 bytes per request.
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perGoroutine; i++ {
+				m.Inc("requests")
+				if i%10 == 0 {
+					m.Inc("errors")
+				}
+				m.Add("bytes", 512)
+			}
+		}()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	snapshots := 0
+	for running := true; running; {
+		select {
+		case <-done:
+			running = false
+		case <-time.After(time.Millisecond):
+			s := m.Snapshot()
+			snapshots++
+			if s["bytes"] > 512*s["requests"] {
+				fmt.Println("inconsistent snapshot:", s)
+			}
+		}
+	}
+
+	s := m.Snapshot()
+	fmt.Printf("took %d snapshots while counting\n", snapshots)
+	fmt.Printf("requests = %d (want %d)\n", s["requests"], goroutines*perGoroutine)
+	fmt.Printf("errors   = %d (want %d)\n", s["errors"], goroutines*perGoroutine/10)
+	fmt.Printf("bytes    = %d (want %d)\n", s["bytes"], goroutines*perGoroutine*512)
+
+	// The snapshot is a copy.
+	s["requests"] = 0
+	fmt.Println("after changing the copy:", m.Snapshot()["requests"])
+}
@@ -0,0 +1,72 @@
+/*
+Named counters, like "requests" or "errors", that many goroutines update at
+the same time.
+
+Two kinds of locking work together here:
+
+  - Every counter is an atomic.Int64, so goroutines can add to the same
+    counter at once without a mutex.
+  - The map of names is protected by a sync.RWMutex. Incrementing only
+    reads the map, so all incrementers share the read lock and never wait
+    for each other. The write lock is only needed to add a new name.
+
+Snapshot takes the write lock too, which waits until no increment is
+half-way done and holds off new ones while it copies the values. The copy is
+therefore consistent: it is the state at one moment, not a mix of earlier
+and later values. Copying a few numbers is fast, so incrementers are only
+held up briefly.
+*/
+package main
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Metrics is a set of counters. The zero value is ready to use, and it is
+// safe to use from several goroutines.
+type Metrics struct {
+	mu       sync.RWMutex
+	counters map[string]*atomic.Int64
+}
+
+func (m *Metrics) Inc(name string) { m.Add(name, 1) }
+
+// Add adds n, which may be negative, to the counter name, creating it at
+// zero first if needed.
+func (m *Metrics) Add(name string, n int64) {
+	m.mu.RLock()
+	c := m.counters[name]
+	if c != nil {
+		c.Add(n)
+		m.mu.RUnlock()
+		return
+	}
+	m.mu.RUnlock()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	// Another goroutine may have created the counter between RUnlock and
+	// Lock, so look again.
+	c = m.counters[name]
+	if c == nil {
+		if m.counters == nil {
+			m.counters = make(map[string]*atomic.Int64)
+		}
+		c = new(atomic.Int64)
+		m.counters[name] = c
+	}
+	c.Add(n)
+}
+
+// Snapshot returns a copy of all the counters as they are at one moment.
+// Changing the copy does not affect m.
+func (m *Metrics) Snapshot() map[string]int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make(map[string]int64, len(m.counters))
+	for name, c := range m.counters {
+		out[name] = c.Load()
+	}
+	return out
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// Run with go test -race *.go.
+func TestMetricsConcurrentTotals(t *testing.T) {
+	const goroutines, perGoroutine = 50, 500
+	var m Metrics
+
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perGoroutine; i++ {
+				m.Inc("requests")
+				if i%10 == 0 {
+					m.Inc("errors")
+				}
+				m.Add("bytes", 512)
+			}
+		}()
+	}
+
+	// Snapshots taken while counting must each be one consistent moment:
+	// requests is always added to before bytes.
+	stop := make(chan struct{})
+	checked := make(chan struct{})
+	go func() {
+		defer close(checked)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			if s := m.Snapshot(); s["bytes"] > 512*s["requests"] {
+				t.Errorf("inconsistent snapshot: %v", s)
+				return
+			}
+		}
+	}()
+	wg.Wait()
+	close(stop)
+	<-checked
+
+	want := map[string]int64{
+		"requests": goroutines * perGoroutine,
+		"errors":   goroutines * perGoroutine / 10,
+		"bytes":    goroutines * perGoroutine * 512,
+	}
+	if got := m.Snapshot(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Snapshot = %v, want %v", got, want)
+	}
+}
+
+func TestMetricsSnapshotIsACopy(t *testing.T) {
+	var m Metrics
+	m.Inc("a")
+	s := m.Snapshot()
+	s["a"] = 100
+	s["b"] = 1
+	if got := m.Snapshot(); !reflect.DeepEqual(got, map[string]int64{"a": 1}) {
+		t.Errorf("Snapshot after changing the copy = %v", got)
+	}
+}
+
+func TestMetricsAdd(t *testing.T) {
+	var m Metrics
+	if got := m.Snapshot(); len(got) != 0 {
+		t.Errorf("zero Metrics: Snapshot = %v", got)
+	}
+	m.Add("balance", 10)
+	m.Add("balance", -25)
+	m.Add("zero", 0)
+	want := map[string]int64{"balance": -15, "zero": 0}
+	if got := m.Snapshot(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Snapshot = %v, want %v", got, want)
+	}
+}
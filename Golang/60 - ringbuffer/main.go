@@ -0,0 +1,46 @@
+package main
+
+import "fmt"
+
+// drain pops everything from r.
+func drain[T any](r *RingBuffer[T]) []T {
+	var out []T
+	for {
+		v, ok := r.Pop()
+		if !ok {
+			return out
+		}
+		out = append(out, v)
+	}
+}
+
+func main() {
+	// Refuse mode: pushes past capacity fail and change nothing.
+	r := NewRingBuffer[int](4, false)
+	for i := 1; i <= 6; i++ {
+		fmt.Printf("Push(%d) = %v, Len = %d\n", i, r.Push(i), r.Len())
+	}
+	fmt.Println("contents:", drain(r))
+
+	// Wrap around: pop two, push two, the new ones go into the freed slots
+	// at the start of the slice.
+	for i := 1; i <= 4; i++ {
+		r.Push(i)
+	}
+	r.Pop()
+	r.Pop()
+	r.Push(5)
+	r.Push(6)
+	fmt.Printf("after wrapping: buf=%v head=%d Len=%d\n", r.buf, r.head, r.Len())
+	fmt.Println("contents:", drain(r))
+
+	// Overwrite mode: keep the last 3 lines of a log.
+	last := NewRingBuffer[string](3, true)
+	for _, line := range []string{"start", "load config", "connect", "listen", "ready"} {
+		last.Push(line)
+	}
+	fmt.Printf("last %d of 5 lines: %q\n", last.Len(), drain(last))
+
+	_, ok := last.Pop()
+	fmt.Println("Pop on empty:", ok)
+}
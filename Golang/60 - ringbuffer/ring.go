@@ -0,0 +1,77 @@
+/*
+A ring buffer (or circular queue) is a FIFO queue in a fixed-size slice
+whose end wraps around to the start. It remembers where the oldest element
+is (head) and how many there are (count); the next free slot is then
+
+	(head + count) % capacity
+
+With capacity 4, after pushing 1..4 and popping 1 and 2, pushing 5 and 6
+fills the slots that 1 and 2 used:
+
+	[5 6 3 4]    head=2, count=4: the order is 3 4 5 6
+
+Nothing is ever moved or reallocated, which makes it a good fit for queues
+with a known maximum size, like the last N log lines. When it is full it can
+either refuse new elements, or, in overwrite mode, drop the oldest one.
+*/
+package main
+
+import "fmt"
+
+// RingBuffer is a FIFO queue of fixed capacity. Create it with
+// NewRingBuffer.
+type RingBuffer[T any] struct {
+	buf       []T
+	head      int // index of the oldest element
+	count     int
+	overwrite bool
+}
+
+// NewRingBuffer returns an empty buffer holding up to capacity elements.
+// With overwrite set, Push on a full buffer replaces the oldest element
+// instead of failing. It panics if capacity is less than 1.
+func NewRingBuffer[T any](capacity int, overwrite bool) *RingBuffer[T] {
+	if capacity < 1 {
+		panic(fmt.Sprintf("ring buffer capacity %d must be at least 1", capacity))
+	}
+	return &RingBuffer[T]{buf: make([]T, capacity), overwrite: overwrite}
+}
+
+// Push adds v at the end. On a full buffer it returns false and drops v,
+// unless the buffer is in overwrite mode: then the oldest element is
+// dropped instead and Push returns true.
+func (r *RingBuffer[T]) Push(v T) bool {
+	if r.count == len(r.buf) {
+		if !r.overwrite {
+			return false
+		}
+		// The slot of the oldest element is exactly the next free one.
+		r.buf[r.head] = v
+		r.head = (r.head + 1) % len(r.buf)
+		return true
+	}
+	r.buf[(r.head+r.count)%len(r.buf)] = v
+	r.count++
+	return true
+}
+
+// Pop removes and returns the oldest element. On an empty buffer it returns
+// the zero value of T and false.
+func (r *RingBuffer[T]) Pop() (T, bool) {
+	var zero T
+	if r.count == 0 {
+		return zero, false
+	}
+	v := r.buf[r.head]
+	// Clear the slot so the buffer does not keep the value alive.
+	r.buf[r.head] = zero
+	r.head = (r.head + 1) % len(r.buf)
+	r.count--
+	return v, true
+}
+
+// Len returns the number of elements in the buffer.
+func (r *RingBuffer[T]) Len() int { return r.count }
+
+// Cap returns the capacity given to NewRingBuffer.
+func (r *RingBuffer[T]) Cap() int { return len(r.buf) }
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRingBufferPastCapacity(t *testing.T) {
+	tests := []struct {
+		name      string
+		overwrite bool
+		push      []int
+		wantOK    []bool
+		want      []int
+	}{
+		{"refuse", false, []int{1, 2, 3, 4, 5, 6}, []bool{true, true, true, true, false, false}, []int{1, 2, 3, 4}},
+		{"overwrite", true, []int{1, 2, 3, 4, 5, 6}, []bool{true, true, true, true, true, true}, []int{3, 4, 5, 6}},
+		{"overwrite twice round", true, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, nil, []int{7, 8, 9, 10}},
+		{"below capacity", false, []int{1, 2}, []bool{true, true}, []int{1, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRingBuffer[int](4, tt.overwrite)
+			for i, v := range tt.push {
+				ok := r.Push(v)
+				if tt.wantOK != nil && ok != tt.wantOK[i] {
+					t.Errorf("Push(%d) = %v, want %v", v, ok, tt.wantOK[i])
+				}
+				if r.Len() > r.Cap() {
+					t.Fatalf("Len %d over Cap %d", r.Len(), r.Cap())
+				}
+			}
+			if r.Len() != len(tt.want) {
+				t.Errorf("Len = %d, want %d", r.Len(), len(tt.want))
+			}
+			if got := drain(r); !slices.Equal(got, tt.want) {
+				t.Errorf("contents = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// Interleaving pushes and pops keeps FIFO order while the head wraps around
+// the slice many times.
+func TestRingBufferFIFOWrapAround(t *testing.T) {
+	r := NewRingBuffer[int](3, false)
+	next, want := 0, 0
+	for round := 0; round < 50; round++ {
+		for r.Push(next) {
+			next++
+		}
+		for i := 0; i < 2; i++ {
+			v, ok := r.Pop()
+			if !ok || v != want {
+				t.Fatalf("round %d: Pop = %d, %v, want %d", round, v, ok, want)
+			}
+			want++
+		}
+	}
+	if got, wantLen := r.Len(), next-want; got != wantLen {
+		t.Errorf("Len = %d, want %d", got, wantLen)
+	}
+}
+
+func TestRingBufferOverwriteAfterPop(t *testing.T) {
+	r := NewRingBuffer[string](3, true)
+	for _, s := range []string{"a", "b", "c"} {
+		r.Push(s)
+	}
+	r.Pop()     // a
+	r.Push("d") // fills the freed slot, nothing dropped
+	r.Push("e") // full: drops b
+	if got, want := drain(r), []string{"c", "d", "e"}; !slices.Equal(got, want) {
+		t.Errorf("contents = %q, want %q", got, want)
+	}
+}
+
+func TestRingBufferEmpty(t *testing.T) {
+	r := NewRingBuffer[int](1, false)
+	if v, ok := r.Pop(); ok || v != 0 {
+		t.Errorf("Pop on empty = %d, %v", v, ok)
+	}
+	r.Push(7)
+	r.Pop()
+	if r.buf[0] != 0 {
+		t.Error("Pop did not clear the slot")
+	}
+}
+
+func TestNewRingBufferPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("NewRingBuffer(0) did not panic")
+		}
+	}()
+	NewRingBuffer[int](0, false)
+}
@@ -0,0 +1,72 @@
+/*
+The dining philosophers: n philosophers sit around a table with one fork
+between each pair of neighbours. To eat, a philosopher needs both the fork
+on the left and the one on the right.
+
+If every philosopher picks up the left fork first, they can all end up
+holding one fork and waiting forever for the other: a deadlock. Here every
+fork is a sync.Mutex with a number, and forks are always picked up lowest
+number first (resource ordering). The last philosopher, sitting between fork
+n-1 and fork 0, therefore reaches for fork 0 first like their neighbour
+does, so the circle of waiting can never close. Any program that takes
+several locks can avoid deadlocks the same way: always lock in the same
+order.
+*/
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Run seats philosophers at the table and lets each of them eat meals
+// times, returning how often each one ate. A single philosopher has only one
+// fork, which is then both the left and the right one.
+func Run(philosophers, meals int) []int {
+	eaten := make([]int, max(philosophers, 0))
+	forks := make([]sync.Mutex, len(eaten))
+
+	var wg sync.WaitGroup
+	for p := range eaten {
+		first, second := p, (p+1)%len(forks)
+		if first > second {
+			first, second = second, first
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < meals; i++ {
+				forks[first].Lock()
+				if second != first {
+					forks[second].Lock()
+				}
+				// Only philosopher p writes eaten[p], so no lock is needed
+				// for the count, and wg.Wait makes it visible to Run.
+				eaten[p]++
+				time.Sleep(time.Millisecond)
+				if second != first {
+					forks[second].Unlock()
+				}
+				forks[first].Unlock()
+				// Think for a moment before getting hungry again.
+				time.Sleep(time.Millisecond)
+			}
+		}()
+	}
+	wg.Wait()
+	return eaten
+}
+
+func main() {
+	for _, n := range []int{5, 1, 2, 0} {
+		const meals = 10
+		eaten := Run(n, meals)
+		total := 0
+		for _, e := range eaten {
+			total += e
+		}
+		fmt.Printf("%d philosophers: ate %v, %d meals in total (want %d)\n",
+			n, eaten, total, n*meals)
+	}
+}
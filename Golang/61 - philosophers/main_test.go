@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+)
+
+// Run with go test -race *.go. A deadlock makes the test time out instead of
+// hanging forever.
+func TestRunTotalMeals(t *testing.T) {
+	tests := []struct {
+		philosophers, meals int
+	}{
+		{5, 10},
+		{5, 0},
+		{2, 5},
+		{1, 3}, // one fork, used as both
+		{0, 10},
+		{-1, 10},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d philosophers, %d meals", tt.philosophers, tt.meals), func(t *testing.T) {
+			done := make(chan []int, 1)
+			go func() { done <- Run(tt.philosophers, tt.meals) }()
+
+			var eaten []int
+			select {
+			case eaten = <-done:
+			case <-time.After(10 * time.Second):
+				t.Fatal("Run did not finish: deadlock?")
+			}
+
+			n := max(tt.philosophers, 0)
+			want := make([]int, n)
+			for i := range want {
+				want[i] = tt.meals
+			}
+			if !slices.Equal(eaten, want) {
+				t.Errorf("ate %v, want %v", eaten, want)
+			}
+			total := 0
+			for _, e := range eaten {
+				total += e
+			}
+			if total != n*tt.meals {
+				t.Errorf("%d meals in total, want %d", total, n*tt.meals)
+			}
+		})
+	}
+}
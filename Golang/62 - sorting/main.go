@@ -0,0 +1,38 @@
+package main
+
+import "fmt"
+
+type Player struct {
+	Name  string
+	Team  string
+	Score int
+}
+
+func main() {
+	players := []Player{
+		{"Ana", "red", 30}, {"Bruno", "blue", 25}, {"Carla", "red", 25},
+		{"Davi", "blue", 30}, {"Elisa", "red", 25}, {"Fabio", "blue", 40},
+	}
+
+	// Sort by team, and by score (highest first) within a team: sort by the
+	// second key first, then by the first one. Because SortBy is stable, the
+	// second sort keeps the score order inside each team, and players with
+	// the same team and score stay in their original (name) order.
+	SortBy(players, func(a, b Player) bool { return a.Score > b.Score })
+	SortBy(players, func(a, b Player) bool { return a.Team < b.Team })
+	for _, p := range players {
+		fmt.Printf("  %-5s %-5s %d\n", p.Team, p.Name, p.Score)
+	}
+
+	nums := []int{42, 7, 19, 88, 3, 61, 7, 25}
+	SortBy(nums, func(a, b int) bool { return a < b })
+	fmt.Println("sorted:", nums)
+
+	best := TopN(players, 3, func(a, b Player) bool { return a.Score < b.Score })
+	fmt.Print("top 3 scores:")
+	for _, p := range best {
+		fmt.Printf(" %s=%d", p.Name, p.Score)
+	}
+	fmt.Println()
+	fmt.Println("TopN(0):", TopN(players, 0, func(a, b Player) bool { return a.Score < b.Score }))
+}
@@ -0,0 +1,99 @@
+/*
+Sorting with a comparison function: less(a, b) reports whether a must come
+before b. The same code then sorts numbers, names, or structs by any field.
+
+SortBy is a merge sort: split the slice in two halves, sort each half, then
+merge them by repeatedly taking the smaller front element. When two elements
+are equal the merge takes the one from the left half first, which makes the
+sort stable: equal elements keep their original order. Stability is what
+allows sorting by several keys: sort by the least important key first and
+by the most important one last.
+
+TopN finds the n largest elements without sorting everything. It keeps the
+best n seen so far in a min-heap, whose top is the smallest of them; a new
+element only needs to beat that one to get in. That is O(len(s) log n)
+instead of O(len(s) log len(s)), a big difference for the top 10 of a
+million.
+*/
+package main
+
+import "container/heap"
+
+// SortBy sorts s in place so that less never holds for an element and the
+// one before it. Equal elements keep their order.
+func SortBy[T any](s []T, less func(a, b T) bool) {
+	if len(s) < 2 {
+		return
+	}
+	tmp := make([]T, len(s))
+	mergeSort(s, tmp, less)
+}
+
+// mergeSort sorts s using tmp, of the same length, as scratch space.
+func mergeSort[T any](s, tmp []T, less func(a, b T) bool) {
+	if len(s) < 2 {
+		return
+	}
+	mid := len(s) / 2
+	mergeSort(s[:mid], tmp[:mid], less)
+	mergeSort(s[mid:], tmp[mid:], less)
+
+	i, j, k := 0, mid, 0
+	for i < mid && j < len(s) {
+		// Only take from the right half when it is strictly smaller: on
+		// ties the left element goes first, which keeps the sort stable.
+		if less(s[j], s[i]) {
+			tmp[k] = s[j]
+			j++
+		} else {
+			tmp[k] = s[i]
+			i++
+		}
+		k++
+	}
+	k += copy(tmp[k:], s[i:mid])
+	copy(tmp[k:], s[j:])
+	copy(s, tmp)
+}
+
+// minHeap implements heap.Interface for TopN, with the smallest element
+// according to less at the top.
+type minHeap[T any] struct {
+	items []T
+	less  func(a, b T) bool
+}
+
+func (h *minHeap[T]) Len() int           { return len(h.items) }
+func (h *minHeap[T]) Less(i, j int) bool { return h.less(h.items[i], h.items[j]) }
+func (h *minHeap[T]) Swap(i, j int)      { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *minHeap[T]) Push(x any)         { h.items = append(h.items, x.(T)) }
+
+func (h *minHeap[T]) Pop() any {
+	last := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return last
+}
+
+// TopN returns the n largest elements of s according to less, largest first,
+// without changing s. For n <= 0 it returns an empty slice, and for n >=
+// len(s) all of s, sorted. Which of several equal elements make the cut is
+// not specified.
+func TopN[T any](s []T, n int, less func(a, b T) bool) []T {
+	n = min(max(n, 0), len(s))
+	h := &minHeap[T]{items: make([]T, 0, n), less: less}
+	for _, v := range s {
+		if h.Len() < n {
+			heap.Push(h, v)
+		} else if n > 0 && less(h.items[0], v) {
+			// v beats the smallest of the best n: it takes its place.
+			h.items[0] = v
+			heap.Fix(h, 0)
+		}
+	}
+	// Popping gives the smallest first, so fill the result from the back.
+	out := make([]T, n)
+	for i := n - 1; i >= 0; i-- {
+		out[i] = heap.Pop(h).(T)
+	}
+	return out
+}
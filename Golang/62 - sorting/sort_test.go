@@ -0,0 +1,93 @@
+package main
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func randomInts(n, limit int) []int {
+	r := rand.New(rand.NewPCG(1, 2)) // fixed seed: same numbers every run
+	nums := make([]int, n)
+	for i := range nums {
+		nums[i] = r.IntN(limit)
+	}
+	return nums
+}
+
+func TestSortByMatchesSlicesSort(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 3, 10, 1000} {
+		nums := randomInts(n, 100)
+		got := slices.Clone(nums)
+		SortBy(got, func(a, b int) bool { return a < b })
+		want := slices.Clone(nums)
+		slices.Sort(want)
+		if !slices.Equal(got, want) {
+			t.Errorf("n = %d: SortBy = %v, want %v", n, got, want)
+		}
+	}
+}
+
+// Sorting by score and then, stably, by team gives team order with the
+// highest scores first inside each team, and ties in input order.
+func TestSortByMultiKey(t *testing.T) {
+	players := []Player{
+		{"Ana", "red", 30}, {"Bruno", "blue", 25}, {"Carla", "red", 25},
+		{"Davi", "blue", 30}, {"Elisa", "red", 25}, {"Fabio", "blue", 40},
+	}
+	SortBy(players, func(a, b Player) bool { return a.Score > b.Score })
+	SortBy(players, func(a, b Player) bool { return a.Team < b.Team })
+
+	want := []Player{
+		{"Fabio", "blue", 40}, {"Davi", "blue", 30}, {"Bruno", "blue", 25},
+		{"Ana", "red", 30}, {"Carla", "red", 25}, {"Elisa", "red", 25},
+	}
+	if !slices.Equal(players, want) {
+		t.Errorf("got %v\nwant %v", players, want)
+	}
+}
+
+func TestSortByIsStable(t *testing.T) {
+	type pair struct{ key, pos int }
+	nums := randomInts(500, 10)
+	pairs := make([]pair, len(nums))
+	for i, k := range nums {
+		pairs[i] = pair{k, i}
+	}
+	SortBy(pairs, func(a, b pair) bool { return a.key < b.key })
+	for i := 1; i < len(pairs); i++ {
+		a, b := pairs[i-1], pairs[i]
+		if a.key > b.key || a.key == b.key && a.pos > b.pos {
+			t.Fatalf("%v before %v", a, b)
+		}
+	}
+}
+
+func TestTopNMatchesFullSort(t *testing.T) {
+	nums := randomInts(200, 50)
+	desc := slices.Clone(nums)
+	slices.Sort(desc)
+	slices.Reverse(desc)
+	byValue := func(a, b int) bool { return a < b }
+
+	orig := slices.Clone(nums)
+	for n := -1; n <= len(nums)+1; n++ {
+		want := desc[:min(max(n, 0), len(nums))]
+		if got := TopN(nums, n, byValue); !slices.Equal(got, want) {
+			t.Fatalf("TopN(%d) = %v, want %v", n, got, want)
+		}
+	}
+	if !slices.Equal(nums, orig) {
+		t.Error("TopN changed its input")
+	}
+}
+
+func TestTopNEdgeCases(t *testing.T) {
+	byValue := func(a, b int) bool { return a < b }
+	if got := TopN([]int{}, 3, byValue); got == nil || len(got) != 0 {
+		t.Errorf("TopN of an empty slice = %#v", got)
+	}
+	if got := TopN([]int{5, 1}, 0, byValue); got == nil || len(got) != 0 {
+		t.Errorf("TopN(0) = %#v", got)
+	}
+}
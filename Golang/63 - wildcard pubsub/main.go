@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+func main() {
+	var ps PubSub
+
+	// received records which patterns got which topics. Handlers may run
+	// in several goroutines at once (see below), hence the mutex.
+	var mu sync.Mutex
+	received := map[string][]string{}
+	patterns := []string{"orders.eu.created", "orders.*.created", "orders.>", "*", ">", "orders.>.x"}
+	for _, p := range patterns {
+		ps.Subscribe(p, func(m Message) {
+			mu.Lock()
+			defer mu.Unlock()
+			received[p] = append(received[p], m.Topic)
+		})
+	}
+
+	topics := []string{"orders.eu.created", "orders.us.created", "orders.created",
+		"orders.eu.x.created", "orders", "users.signup", "orders.>.x"}
+	for _, t := range topics {
+		n := ps.Publish(t, Message{})
+		fmt.Printf("%-20s handlers: %d\n", t, n)
+	}
+	fmt.Println()
+	for _, p := range patterns {
+		fmt.Printf("%-18s got %s\n", p, strings.Join(received[p], ", "))
+	}
+
+	// Subscribing, unsubscribing and publishing from many goroutines.
+	var wg sync.WaitGroup
+	count := 0
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unsubscribe := ps.Subscribe("jobs.*", func(Message) {
+				mu.Lock()
+				count++
+				mu.Unlock()
+			})
+			ps.Publish(fmt.Sprintf("jobs.%d", i), Message{Data: i})
+			unsubscribe()
+			unsubscribe()
+		}()
+	}
+	wg.Wait()
+	// Every goroutine's own handler saw its own message, and maybe others.
+	fmt.Println("\nconcurrent deliveries to jobs.*:", count, "(at least 50)")
+	// All the jobs.* subscriptions are gone, only ">" is left.
+	fmt.Println("handlers for jobs.1 now:", ps.Publish("jobs.1", Message{}))
+}
@@ -0,0 +1,97 @@
+/*
+Publish/subscribe with wildcards. Topics are names made of segments
+separated by dots, like "orders.eu.created", and subscribers choose what to
+receive with a pattern:
+
+	orders.eu.created   only that exact topic
+	orders.*.created    "*" matches any one segment: orders.us.created too,
+	                    but not orders.created or orders.eu.x.created
+	orders.>            a ">" at the end matches one or more segments:
+	                    everything below orders, but not orders itself
+
+Unlike the channels of the event bus example, handlers here are plain
+functions, called by Publish one after the other. Publish copies the list of
+matching handlers under the read lock and calls them after releasing it, so
+a handler may itself Subscribe, Publish or unsubscribe without deadlocking.
+*/
+package main
+
+import (
+	"strings"
+	"sync"
+)
+
+type Message struct {
+	Topic string
+	Data  any
+}
+
+type subscription struct {
+	pattern []string
+	handler func(Message)
+}
+
+// PubSub delivers messages to the handlers whose pattern matches the topic.
+// The zero value is ready to use, and it is safe to use from several
+// goroutines.
+type PubSub struct {
+	mu   sync.RWMutex
+	subs []*subscription
+}
+
+// Subscribe calls handler for every message published from now on to a
+// topic matching pattern. A ">" that is not the last segment is matched as
+// an ordinary segment. The returned function removes the subscription;
+// calling it again does nothing.
+func (ps *PubSub) Subscribe(pattern string, handler func(Message)) (unsubscribe func()) {
+	sub := &subscription{pattern: strings.Split(pattern, "."), handler: handler}
+	ps.mu.Lock()
+	ps.subs = append(ps.subs, sub)
+	ps.mu.Unlock()
+
+	return func() {
+		ps.mu.Lock()
+		defer ps.mu.Unlock()
+		for i, s := range ps.subs {
+			if s == sub {
+				ps.subs = append(ps.subs[:i], ps.subs[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Publish sets m.Topic to topic and calls every matching handler, in the
+// order they subscribed, returning how many were called.
+func (ps *PubSub) Publish(topic string, m Message) int {
+	m.Topic = topic
+	segments := strings.Split(topic, ".")
+
+	ps.mu.RLock()
+	var handlers []func(Message)
+	for _, s := range ps.subs {
+		if match(s.pattern, segments) {
+			handlers = append(handlers, s.handler)
+		}
+	}
+	ps.mu.RUnlock()
+
+	for _, h := range handlers {
+		h(m)
+	}
+	return len(handlers)
+}
+
+// match reports whether the topic segments fit the pattern segments.
+func match(pattern, topic []string) bool {
+	for i, p := range pattern {
+		if p == ">" && i == len(pattern)-1 {
+			// At least one segment must be left for ">" to match.
+			return len(topic) > i
+		}
+		if i >= len(topic) || p != "*" && p != topic[i] {
+			return false
+		}
+	}
+	return len(pattern) == len(topic)
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern, topic string
+		want           bool
+	}{
+		{"orders.eu.created", "orders.eu.created", true},
+		{"orders.eu.created", "orders.us.created", false},
+		{"orders.eu.created", "orders.eu", false},
+		{"orders.*.created", "orders.us.created", true},
+		{"orders.*.created", "orders.created", false},
+		{"orders.*.created", "orders.eu.x.created", false},
+		{"orders.>", "orders.created", true},
+		{"orders.>", "orders.eu.x.created", true},
+		{"orders.>", "orders", false},
+		{"orders.>", "users.signup", false},
+		{"*", "orders", true},
+		{"*", "orders.created", false},
+		{">", "orders", true},
+		{">", "a.b.c", true},
+		{"orders.>.x", "orders.>.x", true},
+		{"orders.>.x", "orders.eu.x", false},
+		{"*.*", "a.b", true},
+		{"*.*", "a", false},
+	}
+	for _, tt := range tests {
+		got := match(strings.Split(tt.pattern, "."), strings.Split(tt.topic, "."))
+		if got != tt.want {
+			t.Errorf("match(%q, %q) = %v, want %v", tt.pattern, tt.topic, got, tt.want)
+		}
+	}
+}
+
+// recorder subscribes to each pattern and records the topics it receives.
+type recorder struct {
+	mu  sync.Mutex
+	got map[string][]string
+}
+
+func subscribeAll(ps *PubSub, patterns []string) *recorder {
+	r := &recorder{got: map[string][]string{}}
+	for _, p := range patterns {
+		ps.Subscribe(p, func(m Message) {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			r.got[p] = append(r.got[p], m.Topic)
+		})
+	}
+	return r
+}
+
+func TestPublishDelivery(t *testing.T) {
+	var ps PubSub
+	patterns := []string{"orders.eu.created", "orders.*.created", "orders.>", "*", ">", "orders.>.x", "users.signup"}
+	r := subscribeAll(&ps, patterns)
+
+	topics := []string{"orders.eu.created", "orders.us.created", "orders.created",
+		"orders.eu.x.created", "orders", "users.signup", "orders.>.x"}
+	wantCounts := []int{4, 3, 2, 2, 2, 2, 3}
+	for i, topic := range topics {
+		if n := ps.Publish(topic, Message{}); n != wantCounts[i] {
+			t.Errorf("Publish(%q) called %d handlers, want %d", topic, n, wantCounts[i])
+		}
+	}
+
+	want := map[string][]string{
+		"orders.eu.created": {"orders.eu.created"},
+		"orders.*.created":  {"orders.eu.created", "orders.us.created"},
+		"orders.>": {"orders.eu.created", "orders.us.created", "orders.created",
+			"orders.eu.x.created", "orders.>.x"},
+		"*":            {"orders"},
+		">":            topics,
+		"orders.>.x":   {"orders.>.x"},
+		"users.signup": {"users.signup"},
+	}
+	for _, p := range patterns {
+		if !slices.Equal(r.got[p], want[p]) {
+			t.Errorf("%q got %q, want %q", p, r.got[p], want[p])
+		}
+	}
+}
+
+func TestPublishMessage(t *testing.T) {
+	var ps PubSub
+	var got Message
+	ps.Subscribe("jobs.*", func(m Message) { got = m })
+	ps.Publish("jobs.7", Message{Topic: "ignored", Data: 7})
+	if got.Topic != "jobs.7" || got.Data != 7 {
+		t.Errorf("handler got %+v, want topic jobs.7 and data 7", got)
+	}
+}
+
+func TestSubscribeOrder(t *testing.T) {
+	var ps PubSub
+	var order []int
+	for i := range 5 {
+		ps.Subscribe("a.*", func(Message) { order = append(order, i) })
+	}
+	ps.Publish("a.b", Message{})
+	if want := []int{0, 1, 2, 3, 4}; !slices.Equal(order, want) {
+		t.Errorf("handlers ran in order %v, want %v", order, want)
+	}
+}
+
+func TestUnsubscribe(t *testing.T) {
+	var ps PubSub
+	calls := map[string]int{}
+	unsubA := ps.Subscribe("x", func(Message) { calls["a"]++ })
+	ps.Subscribe("x", func(Message) { calls["b"]++ })
+
+	ps.Publish("x", Message{})
+	unsubA()
+	unsubA() // a second call must not remove b
+	if n := ps.Publish("x", Message{}); n != 1 {
+		t.Errorf("after unsubscribe Publish called %d handlers, want 1", n)
+	}
+	if calls["a"] != 1 || calls["b"] != 2 {
+		t.Errorf("calls = %v, want a=1 b=2", calls)
+	}
+}
+
+// A handler may use the PubSub it was called from without deadlocking.
+func TestHandlerReentry(t *testing.T) {
+	var ps PubSub
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		var unsub func()
+		unsub = ps.Subscribe("ping", func(Message) {
+			ps.Subscribe("pong", func(Message) {})
+			ps.Publish("pong", Message{})
+			unsub()
+		})
+		ps.Publish("ping", Message{})
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("handler that used the PubSub deadlocked")
+	}
+	if n := ps.Publish("ping", Message{}); n != 0 {
+		t.Errorf("ping has %d handlers after unsubscribing inside one, want 0", n)
+	}
+}
+
+// Run with go test -race *.go.
+func TestConcurrent(t *testing.T) {
+	var ps PubSub
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	own := map[int]bool{}
+	for i := range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unsubscribe := ps.Subscribe("jobs.*", func(m Message) {
+				if m.Data == i {
+					mu.Lock()
+					own[i] = true
+					mu.Unlock()
+				}
+			})
+			ps.Publish("jobs.x", Message{Data: i})
+			unsubscribe()
+		}()
+	}
+	wg.Wait()
+	if len(own) != 50 {
+		t.Errorf("%d of 50 subscribers saw their own message", len(own))
+	}
+	if n := ps.Publish("jobs.x", Message{}); n != 0 {
+		t.Errorf("%d handlers left after every unsubscribe, want 0", n)
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+func main() {
+	// 10 downloads, at most 3 at a time.
+	sem := NewSemaphore(3)
+	var running, peak atomic.Int64
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := sem.Acquire(context.Background()); err != nil {
+				return
+			}
+			defer sem.Release()
+			n := running.Add(1)
+			// peak = max(peak, n), retried if another goroutine changed
+			// peak in between.
+			for p := peak.Load(); n > p && !peak.CompareAndSwap(p, n); p = peak.Load() {
+			}
+			time.Sleep(10 * time.Millisecond) // the download
+			running.Add(-1)
+		}()
+	}
+	wg.Wait()
+	fmt.Println("most downloads at once:", peak.Load())
+
+	// Saturated: every permit taken, TryAcquire fails at once and Acquire
+	// gives up when its context times out.
+	for sem.TryAcquire() {
+	}
+	fmt.Println("permits in use:", sem.InUse())
+	start := time.Now()
+	fmt.Println("TryAcquire:", sem.TryAcquire(), "after", time.Since(start).Round(time.Millisecond))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := sem.Acquire(ctx)
+	fmt.Println("Acquire:", err, errors.Is(err, context.DeadlineExceeded))
+
+	// A release lets one waiting Acquire through.
+	done := make(chan error)
+	go func() { done <- sem.Acquire(context.Background()) }()
+	time.Sleep(10 * time.Millisecond)
+	sem.Release()
+	fmt.Println("waiting Acquire after Release:", <-done)
+
+	for sem.InUse() > 0 {
+		sem.Release()
+	}
+	defer func() { fmt.Println("recovered:", recover()) }()
+	sem.Release()
+}
@@ -0,0 +1,73 @@
+/*
+A semaphore limits how many goroutines may do something at the same time,
+for example at most 3 downloads at once. It holds a number of permits:
+Acquire takes one, waiting while there are none left, and Release gives it
+back.
+
+A buffered channel is a semaphore already. Its capacity is the number of
+permits, and every value in it is a permit in use: Acquire sends (and blocks
+while the channel is full), Release receives. With select, the same send can
+give up when the context is cancelled, or not wait at all with a default
+case.
+*/
+package main
+
+import (
+	"context"
+	"fmt"
+)
+
+// Semaphore is safe to use from several goroutines. Create it with
+// NewSemaphore.
+type Semaphore struct {
+	permits chan struct{}
+}
+
+// NewSemaphore returns a semaphore with n permits. It panics if n is less
+// than 1.
+func NewSemaphore(n int) *Semaphore {
+	if n < 1 {
+		panic(fmt.Sprintf("semaphore needs at least 1 permit, got %d", n))
+	}
+	return &Semaphore{permits: make(chan struct{}, n)}
+}
+
+// Acquire takes a permit, waiting until one is free. It returns ctx.Err(),
+// without taking a permit, if ctx is done first.
+func (s *Semaphore) Acquire(ctx context.Context) error {
+	// select picks at random when several cases are ready, so without this
+	// check an already cancelled ctx could still get a permit.
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	select {
+	case s.permits <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// TryAcquire takes a permit if one is free right now and reports whether it
+// did. It never waits.
+func (s *Semaphore) TryAcquire() bool {
+	select {
+	case s.permits <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// Release gives back a permit. Like unlocking an unlocked sync.Mutex,
+// releasing more permits than were acquired is a bug, and Release panics.
+func (s *Semaphore) Release() {
+	select {
+	case <-s.permits:
+	default:
+		panic("semaphore: Release without Acquire")
+	}
+}
+
+// InUse returns how many permits are taken at the moment.
+func (s *Semaphore) InUse() int { return len(s.permits) }
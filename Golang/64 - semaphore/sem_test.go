@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Run with go test -race *.go.
+func TestAcquireLimitsConcurrency(t *testing.T) {
+	for _, permits := range []int{1, 3, 8} {
+		sem := NewSemaphore(permits)
+		var running, peak atomic.Int64
+		var wg sync.WaitGroup
+		for range 50 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := sem.Acquire(context.Background()); err != nil {
+					t.Error(err)
+					return
+				}
+				defer sem.Release()
+				n := running.Add(1)
+				for p := peak.Load(); n > p && !peak.CompareAndSwap(p, n); p = peak.Load() {
+				}
+				time.Sleep(time.Millisecond)
+				running.Add(-1)
+			}()
+		}
+		wg.Wait()
+		if p := peak.Load(); p > int64(permits) {
+			t.Errorf("%d permits: %d goroutines held one at once", permits, p)
+		}
+		if n := sem.InUse(); n != 0 {
+			t.Errorf("%d permits: InUse = %d after every Release, want 0", permits, n)
+		}
+	}
+}
+
+// saturate takes every permit of sem from other goroutines, which hold them
+// until release is closed.
+func saturate(t *testing.T, sem *Semaphore, permits int) (release func()) {
+	t.Helper()
+	stop := make(chan struct{})
+	var held, done sync.WaitGroup
+	for range permits {
+		held.Add(1)
+		done.Add(1)
+		go func() {
+			defer done.Done()
+			if err := sem.Acquire(context.Background()); err != nil {
+				t.Error(err)
+			}
+			held.Done()
+			<-stop
+			sem.Release()
+		}()
+	}
+	held.Wait()
+	return func() { close(stop); done.Wait() }
+}
+
+func TestTryAcquireSaturated(t *testing.T) {
+	sem := NewSemaphore(4)
+	release := saturate(t, sem, 4)
+	if n := sem.InUse(); n != 4 {
+		t.Fatalf("InUse = %d with every permit held, want 4", n)
+	}
+
+	// However many goroutines try, none gets a permit, and none waits.
+	var wg sync.WaitGroup
+	var got atomic.Int64
+	start := time.Now()
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				if sem.TryAcquire() {
+					got.Add(1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if n := got.Load(); n != 0 {
+		t.Errorf("TryAcquire succeeded %d times on a saturated semaphore", n)
+	}
+	if d := time.Since(start); d > 2*time.Second {
+		t.Errorf("TryAcquire took %v; it must not wait", d)
+	}
+
+	release()
+	if !sem.TryAcquire() {
+		t.Error("TryAcquire failed after the permits were released")
+	}
+	sem.Release()
+}
+
+func TestAcquireCancelledWhileWaiting(t *testing.T) {
+	sem := NewSemaphore(2)
+	release := saturate(t, sem, 2)
+	defer release()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errc := make(chan error)
+	go func() { errc <- sem.Acquire(ctx) }()
+
+	select {
+	case err := <-errc:
+		t.Fatalf("Acquire returned %v on a saturated semaphore", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+	cancel()
+	select {
+	case err := <-errc:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Acquire = %v, want context.Canceled", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Acquire kept waiting after its context was cancelled")
+	}
+	if n := sem.InUse(); n != 2 {
+		t.Errorf("InUse = %d after a cancelled Acquire, want 2", n)
+	}
+}
+
+func TestAcquireDeadline(t *testing.T) {
+	sem := NewSemaphore(1)
+	release := saturate(t, sem, 1)
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := sem.Acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Acquire = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+// An already cancelled context never gets a permit, even when one is free.
+func TestAcquireAlreadyCancelled(t *testing.T) {
+	sem := NewSemaphore(1)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for range 100 {
+		if err := sem.Acquire(ctx); !errors.Is(err, context.Canceled) {
+			t.Fatalf("Acquire = %v, want context.Canceled", err)
+		}
+	}
+	if n := sem.InUse(); n != 0 {
+		t.Errorf("InUse = %d, want 0", n)
+	}
+}
+
+func TestReleaseWakesWaiter(t *testing.T) {
+	sem := NewSemaphore(1)
+	if err := sem.Acquire(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	errc := make(chan error)
+	go func() { errc <- sem.Acquire(context.Background()) }()
+	sem.Release()
+	select {
+	case err := <-errc:
+		if err != nil {
+			t.Errorf("waiting Acquire = %v, want nil", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("waiting Acquire did not get the released permit")
+	}
+	if n := sem.InUse(); n != 1 {
+		t.Errorf("InUse = %d, want 1", n)
+	}
+}
+
+func TestPanics(t *testing.T) {
+	tests := []struct {
+		name string
+		f    func()
+	}{
+		{"NewSemaphore(0)", func() { NewSemaphore(0) }},
+		{"NewSemaphore(-1)", func() { NewSemaphore(-1) }},
+		{"Release without Acquire", func() { NewSemaphore(2).Release() }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s did not panic", tt.name)
+				}
+			}()
+			tt.f()
+		})
+	}
+}
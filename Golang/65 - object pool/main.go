@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// resets counts the calls of the reset hook.
+var resets atomic.Int64
+
+var buffers = NewPool(
+	func() *bytes.Buffer { return new(bytes.Buffer) },
+	func(b **bytes.Buffer) {
+		resets.Add(1)
+		// Very large buffers are replaced, so one huge response does not
+		// keep its memory in the pool for ever.
+		if (*b).Cap() > 64*1024 {
+			*b = new(bytes.Buffer)
+			return
+		}
+		(*b).Reset()
+	},
+)
+
+// render builds a small response in buf.
+func render(buf *bytes.Buffer, id int) {
+	fmt.Fprintf(buf, `{"id": %d, "status": "ok", "items": [1, 2, 3]}`, id)
+}
+
+func main() {
+	// A buffer comes back empty: reset ran on Put. sync.Pool does not
+	// promise to hand out the same buffer again, but without other
+	// goroutines and garbage collections in between it usually does.
+	buf := buffers.Get()
+	render(buf, 1)
+	fmt.Printf("before Put: %d bytes\n", buf.Len())
+	buffers.Put(buf)
+	again := buffers.Get()
+	fmt.Printf("after Get:  %d bytes, same buffer: %v, resets: %d\n",
+		again.Len(), again == buf, resets.Load())
+	buffers.Put(again)
+
+	// Many goroutines sharing the pool; each one checks it never gets a
+	// buffer with someone else's data in it.
+	var wg sync.WaitGroup
+	var dirty atomic.Int64
+	for g := 0; g < 20; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				buf := buffers.Get()
+				if buf.Len() != 0 {
+					dirty.Add(1)
+				}
+				render(buf, i)
+				buffers.Put(buf)
+			}
+		}()
+	}
+	wg.Wait()
+	fmt.Printf("20000 concurrent Get/Put: %d dirty buffers, %d resets\n", dirty.Load(), resets.Load())
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"sync"
+	"testing"
+)
+
+type counter struct {
+	n    int
+	name string
+}
+
+func TestPutResets(t *testing.T) {
+	var resets int
+	p := NewPool(
+		func() *counter { return &counter{} },
+		func(c **counter) {
+			resets++
+			**c = counter{}
+		},
+	)
+
+	tests := []struct {
+		name string
+		n    int
+		tag  string
+	}{
+		{"zero value", 0, ""},
+		{"dirty", 42, "kept"},
+		{"dirty again", 7, "x"},
+	}
+	for i, tt := range tests {
+		c := p.Get()
+		if c.n != 0 || c.name != "" {
+			t.Errorf("%s: Get returned a dirty value %+v", tt.name, *c)
+		}
+		c.n, c.name = tt.n, tt.tag
+		p.Put(c)
+		// c is a pointer, so the reset is visible through it.
+		if c.n != 0 || c.name != "" {
+			t.Errorf("%s: after Put the value is %+v, want it reset", tt.name, *c)
+		}
+		if resets != i+1 {
+			t.Errorf("%s: reset called %d times, want %d", tt.name, resets, i+1)
+		}
+	}
+}
+
+// reset may replace the value: the replacement is what goes into the pool.
+// sync.Pool may drop it, so Get returns either the replacement or a value
+// from factory, never the one that was put back.
+func TestResetReplaces(t *testing.T) {
+	fresh := &counter{name: "replacement"}
+	put := &counter{n: 1}
+	var seen *counter
+	p := NewPool(
+		func() *counter { return &counter{} },
+		func(c **counter) {
+			seen = *c
+			*c = fresh
+		},
+	)
+	p.Put(put)
+	if seen != put {
+		t.Errorf("reset got %p, want the value passed to Put (%p)", seen, put)
+	}
+	got := p.Get()
+	if got == put {
+		t.Fatal("Get returned the value that reset replaced")
+	}
+	if got != fresh && *got != (counter{}) {
+		t.Errorf("Get after Put = %+v, want the replacement or a new value", *got)
+	}
+}
+
+func TestNilReset(t *testing.T) {
+	made := 0
+	p := NewPool(func() *counter { made++; return &counter{} }, nil)
+	c := p.Get()
+	p.Put(c)
+	if made != 1 {
+		t.Errorf("factory called %d times, want 1", made)
+	}
+}
+
+// Run with -race: no value is ever handed to two goroutines at once, and
+// none comes out dirty.
+func TestConcurrentUse(t *testing.T) {
+	p := NewPool(
+		func() *counter { return &counter{} },
+		func(c **counter) { (*c).n = 0 },
+	)
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				c := p.Get()
+				if c.n != 0 {
+					t.Errorf("got a dirty value %+v", *c)
+					return
+				}
+				c.n = i + 1
+				p.Put(c)
+			}
+		}()
+	}
+	wg.Wait()
+}
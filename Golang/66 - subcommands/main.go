@@ -0,0 +1,237 @@
+/*
+A command line tool with subcommands, like "go build" or "git commit": the
+first argument picks the command, and every command has its own flags.
+
+Usage:
+
+	go run main.go add [-file tasks.txt] [-priority n] text...
+	go run main.go list [-file tasks.txt] [-min n]
+	go run main.go remove [-file tasks.txt] -n number
+
+Every subcommand parses the rest of the arguments with its own
+flag.FlagSet, so "list -priority 2" is an error: -priority only exists for
+add. ContinueOnError makes a FlagSet return its errors instead of calling
+os.Exit, which lets Run return an exit code and be called from code. Run
+with no arguments to see a demonstration.
+*/
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+type task struct {
+	priority int
+	text     string
+}
+
+// loadTasks reads the tasks in path, one "priority<TAB>text" per line. A
+// file that does not exist yet holds no tasks.
+func loadTasks(path string) ([]task, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var tasks []task
+	sc := bufio.NewScanner(f)
+	for line := 1; sc.Scan(); line++ {
+		p, text, _ := strings.Cut(sc.Text(), "\t")
+		priority, err := strconv.Atoi(p)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: bad priority %q", path, line, p)
+		}
+		tasks = append(tasks, task{priority, text})
+	}
+	return tasks, sc.Err()
+}
+
+func saveTasks(path string, tasks []task) error {
+	var sb strings.Builder
+	for _, t := range tasks {
+		fmt.Fprintf(&sb, "%d\t%s\n", t.priority, t.text)
+	}
+	return os.WriteFile(path, []byte(sb.String()), 0o644)
+}
+
+// A command parses its own arguments and does its work, returning an error
+// for Run to print.
+type command struct {
+	usage string
+	run   func(args []string, out io.Writer) error
+}
+
+var commands = map[string]command{
+	"add":    {"add [-file path] [-priority n] text...", runAdd},
+	"list":   {"list [-file path] [-min n]", runList},
+	"remove": {"remove [-file path] -n number", runRemove},
+}
+
+// errUsage marks errors in the command line itself, as opposed to errors
+// while doing the work.
+var errUsage = errors.New("usage")
+
+// newFlagSet returns a FlagSet for the named command, already holding the
+// -file flag every command has, and writing its messages to out.
+func newFlagSet(name string, out io.Writer) (*flag.FlagSet, *string) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(out)
+	file := fs.String("file", "tasks.txt", "the task list")
+	return fs, file
+}
+
+// parse parses args with fs, marking a bad command line with errUsage. The
+// FlagSet prints the error and the list of flags by itself.
+func parse(fs *flag.FlagSet, args []string) error {
+	err := fs.Parse(args)
+	if err == nil || errors.Is(err, flag.ErrHelp) {
+		return err
+	}
+	return fmt.Errorf("%w: %w", errUsage, err)
+}
+
+func runAdd(args []string, out io.Writer) error {
+	fs, file := newFlagSet("add", out)
+	priority := fs.Int("priority", 1, "the priority, higher is more urgent")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	text := strings.Join(fs.Args(), " ")
+	if text == "" {
+		fmt.Fprintln(out, "add: nothing to add")
+		return errUsage
+	}
+	tasks, err := loadTasks(*file)
+	if err != nil {
+		return fmt.Errorf("add: %w", err)
+	}
+	tasks = append(tasks, task{*priority, text})
+	if err := saveTasks(*file, tasks); err != nil {
+		return fmt.Errorf("add: %w", err)
+	}
+	fmt.Fprintf(out, "added task %d\n", len(tasks))
+	return nil
+}
+
+func runList(args []string, out io.Writer) error {
+	fs, file := newFlagSet("list", out)
+	minPriority := fs.Int("min", 0, "only list tasks with at least this priority")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	tasks, err := loadTasks(*file)
+	if err != nil {
+		return fmt.Errorf("list: %w", err)
+	}
+	for i, t := range tasks {
+		if t.priority >= *minPriority {
+			fmt.Fprintf(out, "%d. [%d] %s\n", i+1, t.priority, t.text)
+		}
+	}
+	return nil
+}
+
+func runRemove(args []string, out io.Writer) error {
+	fs, file := newFlagSet("remove", out)
+	n := fs.Int("n", 0, "the number of the task to remove, as shown by list")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	tasks, err := loadTasks(*file)
+	if err != nil {
+		return fmt.Errorf("remove: %w", err)
+	}
+	if *n < 1 || *n > len(tasks) {
+		return fmt.Errorf("remove: no task number %d", *n)
+	}
+	removed := tasks[*n-1]
+	tasks = append(tasks[:*n-1], tasks[*n:]...)
+	if err := saveTasks(*file, tasks); err != nil {
+		return fmt.Errorf("remove: %w", err)
+	}
+	fmt.Fprintf(out, "removed %q\n", removed.text)
+	return nil
+}
+
+func usage(out io.Writer) {
+	fmt.Fprintln(out, "usage: tasks <command> [flags]")
+	for _, name := range []string{"add", "list", "remove"} {
+		fmt.Fprintln(out, "  tasks", commands[name].usage)
+	}
+}
+
+// Run executes the command in args, which do not include the program name,
+// writing all its output to out. It returns the exit code: 0 on success, 1
+// when the command failed and 2 for a wrong command line, like flag does.
+func Run(args []string, out io.Writer) int {
+	if len(args) == 0 {
+		usage(out)
+		return 2
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(out, "unknown command %q\n", args[0])
+		usage(out)
+		return 2
+	}
+	err := cmd.run(args[1:], out)
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, flag.ErrHelp):
+		// -h or -help: the FlagSet already printed the flags.
+		return 0
+	case errors.Is(err, errUsage):
+		// The error was printed already, with the flags.
+		return 2
+	default:
+		fmt.Fprintln(out, "error:", err)
+		return 1
+	}
+}
+
+func main() {
+	if len(os.Args) > 1 {
+		os.Exit(Run(os.Args[1:], os.Stdout))
+	}
+
+	// The demonstration keeps its task list in a temporary directory.
+	dir, err := os.MkdirTemp("", "tasks")
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	defer os.RemoveAll(dir)
+	file := "-file=" + filepath.Join(dir, "tasks.txt")
+
+	for _, args := range [][]string{
+		{"add", file, "-priority", "3", "write", "the", "report"},
+		{"add", file, "buy milk"},
+		{"add", file, "-priority=2", "call the bank"},
+		{"add", file, "-priority", "high", "panic"},
+		{"add", file},
+		{"list", file},
+		{"list", file, "-min", "2"},
+		{"remove", file, "-n", "2"},
+		{"list", file},
+		{"list", file, "-priority", "2"},
+		{"remove", file, "-n", "9"},
+		{"deploy"},
+	} {
+		fmt.Printf("$ tasks %s\n", strings.ReplaceAll(strings.Join(args, " "), dir, "$TMP"))
+		code := Run(args, os.Stdout)
+		fmt.Printf("(exit code %d)\n\n", code)
+	}
+}
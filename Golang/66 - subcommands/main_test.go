@@ -0,0 +1,93 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// run calls Run and returns its exit code and everything it wrote.
+func run(args ...string) (int, string) {
+	var out strings.Builder
+	code := Run(args, &out)
+	return code, out.String()
+}
+
+func TestRunHappyPath(t *testing.T) {
+	file := "-file=" + filepath.Join(t.TempDir(), "tasks.txt")
+	steps := []struct {
+		args []string
+		out  string
+	}{
+		{[]string{"list", file}, ""},
+		{[]string{"add", file, "-priority", "3", "write", "the", "report"}, "added task 1\n"},
+		{[]string{"add", file, "buy milk"}, "added task 2\n"},
+		{[]string{"add", file, "-priority=2", "call the bank"}, "added task 3\n"},
+		{[]string{"list", file}, "1. [3] write the report\n2. [1] buy milk\n3. [2] call the bank\n"},
+		{[]string{"list", file, "-min", "2"}, "1. [3] write the report\n3. [2] call the bank\n"},
+		{[]string{"remove", file, "-n", "2"}, "removed \"buy milk\"\n"},
+		{[]string{"list", file}, "1. [3] write the report\n2. [2] call the bank\n"},
+	}
+	for _, s := range steps {
+		code, out := run(s.args...)
+		if code != 0 || out != s.out {
+			t.Fatalf("%v: exit code %d, output %q; want 0, %q", s.args[:1], code, out, s.out)
+		}
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	dir := t.TempDir()
+	file := "-file=" + filepath.Join(dir, "tasks.txt")
+	if code, out := run("add", file, "only task"); code != 0 {
+		t.Fatalf("add: exit code %d, output %q", code, out)
+	}
+	bad := filepath.Join(dir, "bad.txt")
+	if err := os.WriteFile(bad, []byte("1\tok\nhigh\tnot a number\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		code int
+		want []string // substrings of the output
+	}{
+		{"no command", nil, 2, []string{"usage: tasks <command> [flags]", "tasks list"}},
+		{"unknown command", []string{"deploy", "-n", "1"}, 2,
+			[]string{`unknown command "deploy"`, "usage: tasks <command> [flags]"}},
+		{"flag of another command", []string{"list", file, "-priority", "2"}, 2,
+			[]string{"flag provided but not defined: -priority", "-min"}},
+		{"-n not a number", []string{"remove", file, "-n", "two"}, 2,
+			[]string{`invalid value "two" for flag -n`}},
+		{"-n too large", []string{"remove", file, "-n", "9"}, 1,
+			[]string{"error: remove: no task number 9"}},
+		{"-n missing", []string{"remove", file}, 1,
+			[]string{"error: remove: no task number 0"}},
+		{"bad priority flag", []string{"add", file, "-priority", "high", "x"}, 2,
+			[]string{`invalid value "high" for flag -priority`}},
+		{"nothing to add", []string{"add", file}, 2, []string{"add: nothing to add"}},
+		{"bad file", []string{"list", "-file=" + bad}, 1,
+			[]string{"error: list: " + bad + `:2: bad priority "high"`}},
+		{"help", []string{"add", "-h"}, 0, []string{"-priority int"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, out := run(tt.args...)
+			if code != tt.code {
+				t.Errorf("exit code %d, want %d; output:\n%s", code, tt.code, out)
+			}
+			for _, w := range tt.want {
+				if !strings.Contains(out, w) {
+					t.Errorf("output does not contain %q:\n%s", w, out)
+				}
+			}
+		})
+	}
+
+	// None of the failed commands changed the list.
+	if _, out := run("list", file); out != "1. [1] only task\n" {
+		t.Errorf("list after the errors = %q", out)
+	}
+}
@@ -0,0 +1,80 @@
+/*
+The Levenshtein distance between two words is the smallest number of single
+letter edits (insert, delete or replace a letter) that turn one into the
+other. "kitten" to "sitting" takes 3: k→s, e→i, and add a g.
+
+It is computed with a table where cell [i][j] is the distance between the
+first i letters of a and the first j letters of b. Every row only depends on
+the one above it, so two rows are enough, each as long as the shorter word.
+
+Suggest uses it for "did you mean ...?" messages: the candidates closest to
+what was typed, the closest first.
+*/
+package main
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// Levenshtein returns the edit distance between a and b, counting runes, so
+// "é" is one letter. The distance to an empty string is the length of the
+// other one.
+func Levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	// Make rb the shorter one: the rows are as long as rb.
+	if len(ra) < len(rb) {
+		ra, rb = rb, ra
+	}
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	// Row 0: turning "" into the first j runes of rb takes j inserts.
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i // deleting all i runes
+		for j := 1; j <= len(rb); j++ {
+			replace := prev[j-1]
+			if ra[i-1] != rb[j-1] {
+				replace++
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, replace)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
+
+// Suggest returns the candidates at most maxDist edits away from input,
+// sorted by distance and then alphabetically, or an empty slice if none is
+// close enough. With ignoreCase, "LIST" and "list" are 0 edits apart.
+func Suggest(input string, candidates []string, maxDist int, ignoreCase bool) []string {
+	type match struct {
+		word string
+		dist int
+	}
+	if ignoreCase {
+		input = strings.ToLower(input)
+	}
+	var matches []match
+	for _, c := range candidates {
+		compared := c
+		if ignoreCase {
+			compared = strings.ToLower(c)
+		}
+		if d := Levenshtein(input, compared); d <= maxDist {
+			matches = append(matches, match{c, d})
+		}
+	}
+	slices.SortFunc(matches, func(x, y match) int {
+		return cmp.Or(cmp.Compare(x.dist, y.dist), strings.Compare(x.word, y.word))
+	})
+
+	out := make([]string, len(matches))
+	for i, m := range matches {
+		out[i] = m.word
+	}
+	return out
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"", "abc", 3},
+		{"abc", "", 3},
+		{"same", "same", 0},
+		{"kitten", "sitting", 3},
+		{"flaw", "lawn", 2},
+		{"saturday", "sunday", 3},
+		{"ab", "ba", 2},
+		{"a", "b", 1},
+		{"ação", "acao", 2}, // runes, not bytes: two replacements
+		{"日本語", "日本", 1},
+		{"Go", "go", 1},
+	}
+	for _, tt := range tests {
+		if got := Levenshtein(tt.a, tt.b); got != tt.want {
+			t.Errorf("Levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+		// The distance is symmetric.
+		if got := Levenshtein(tt.b, tt.a); got != tt.want {
+			t.Errorf("Levenshtein(%q, %q) = %d, want %d", tt.b, tt.a, got, tt.want)
+		}
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	commands := []string{"build", "clean", "list", "install", "test", "lint", "status"}
+	tests := []struct {
+		name       string
+		input      string
+		candidates []string
+		maxDist    int
+		ignoreCase bool
+		want       []string
+	}{
+		{"typo", "biuld", commands, 2, false, []string{"build"}},
+		{"ties sorted alphabetically", "lsit", commands, 2, false, []string{"lint", "list"}},
+		{"closest first", "lint", commands, 2, false, []string{"lint", "list"}},
+		{"case matters by default", "LIST", commands, 2, false, []string{}},
+		{"ignoring case", "LIST", commands, 2, true, []string{"list", "lint", "test"}},
+		{"keeps the original spelling", "init", []string{"Init", "INSTALL"}, 0, true, []string{"Init"}},
+		{"nothing close", "xyz", commands, 2, false, []string{}},
+		{"exact only", "test", commands, 0, false, []string{"test"}},
+		{"empty input", "", []string{"a", "ab", "abc"}, 2, false, []string{"a", "ab"}},
+		{"empty candidate", "a", []string{"", "b"}, 1, false, []string{"", "b"}},
+		{"no candidates", "a", nil, 5, false, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Suggest(tt.input, tt.candidates, tt.maxDist, tt.ignoreCase)
+			if got == nil || !slices.Equal(got, tt.want) {
+				t.Errorf("Suggest(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+func main() {
+	pairs := [][2]string{
+		{"kitten", "sitting"}, {"flaw", "lawn"}, {"", ""}, {"", "abc"},
+		{"abc", ""}, {"same", "same"}, {"ação", "acao"}, {"日本語", "日本"},
+	}
+	for _, p := range pairs {
+		fmt.Printf("Levenshtein(%q, %q) = %d\n", p[0], p[1], Levenshtein(p[0], p[1]))
+	}
+
+	commands := []string{"build", "clean", "list", "install", "test", "lint", "status"}
+	for _, typed := range []string{"biuld", "lsit", "LIST", "tset", "xyz", ""} {
+		exact := Suggest(typed, commands, 2, false)
+		folded := Suggest(typed, commands, 2, true)
+		fmt.Printf("unknown command %q, did you mean: %s (ignoring case: %s)\n",
+			typed, orNone(exact), orNone(folded))
+	}
+}
+
+func orNone(words []string) string {
+	if len(words) == 0 {
+		return "-"
+	}
+	return strings.Join(words, ", ")
+}
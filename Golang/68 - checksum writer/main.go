@@ -0,0 +1,98 @@
+/*
+Wrapping an io.Writer: HashingWriter passes everything written to it on to
+another writer, and feeds the same bytes into a hash on the way. Copying a
+download to a file and computing its SHA-256 then takes a single pass over
+the data, instead of writing the file and reading it back.
+
+Any type with a Write([]byte) (int, error) method is an io.Writer, so
+HashingWriter works with io.Copy, fmt.Fprintf, bufio and everything else
+that writes. The io.Writer rules say Write must return an error whenever it
+writes fewer bytes than it was given; HashingWriter only hashes the bytes
+that really were written, so the sum always matches the destination.
+main_test.go checks that with writers that fail or write too little:
+
+	go test *.go
+*/
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// HashingWriter writes to an underlying writer and hashes what it wrote.
+// Create it with NewHashingWriter.
+type HashingWriter struct {
+	w io.Writer
+	h hash.Hash
+}
+
+// NewHashingWriter returns a writer forwarding to w and hashing with h, for
+// example sha256.New().
+func NewHashingWriter(w io.Writer, h hash.Hash) *HashingWriter {
+	return &HashingWriter{w: w, h: h}
+}
+
+// Write writes p to the underlying writer and hashes the bytes it accepted.
+// A short write without an error is reported as io.ErrShortWrite.
+func (hw *HashingWriter) Write(p []byte) (int, error) {
+	n, err := hw.w.Write(p)
+	// hash.Hash.Write never returns an error.
+	hw.h.Write(p[:n])
+	if err == nil && n < len(p) {
+		err = io.ErrShortWrite
+	}
+	return n, err
+}
+
+// Sum returns the hash of everything written so far. Writing can go on
+// afterwards.
+func (hw *HashingWriter) Sum() []byte {
+	return hw.h.Sum(nil)
+}
+
+func main() {
+	data := []byte(strings.Repeat("The quick brown fox jumps over the lazy dog. ", 1000))
+	want := sha256.Sum256(data)
+	fmt.Println("one-shot sha256:", hex.EncodeToString(want[:]))
+
+	// Into a file, in chunks of 7 bytes.
+	dir, err := os.MkdirTemp("", "checksum")
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	defer os.RemoveAll(dir)
+	f, err := os.Create(filepath.Join(dir, "fox.txt"))
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	defer f.Close()
+	hw := NewHashingWriter(f, sha256.New())
+	for rest := data; len(rest) > 0; {
+		chunk := rest[:min(7, len(rest))]
+		if _, err := hw.Write(chunk); err != nil {
+			fmt.Println("Error:", err)
+			return
+		}
+		rest = rest[len(chunk):]
+	}
+	fmt.Println("chunked writes: ", hex.EncodeToString(hw.Sum()), bytes.Equal(hw.Sum(), want[:]))
+
+	// Through io.Copy, which uses its own chunk size.
+	var buf bytes.Buffer
+	hw = NewHashingWriter(&buf, sha256.New())
+	if _, err := io.Copy(hw, bytes.NewReader(data)); err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+	fmt.Println("io.Copy:        ", bytes.Equal(hw.Sum(), want[:]), "and", buf.Len(), "bytes copied")
+}
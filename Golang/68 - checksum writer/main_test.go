@@ -0,0 +1,159 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// limitedWriter accepts at most limit bytes in total, then fails, like a full
+// disk.
+type limitedWriter struct {
+	buf   bytes.Buffer
+	limit int
+}
+
+var errDiskFull = errors.New("disk full")
+
+func (lw *limitedWriter) Write(p []byte) (int, error) {
+	room := lw.limit - lw.buf.Len()
+	if len(p) <= room {
+		return lw.buf.Write(p)
+	}
+	lw.buf.Write(p[:room])
+	return room, errDiskFull
+}
+
+// shortWriter breaks the io.Writer rules: it writes half of every chunk and
+// returns no error.
+type shortWriter struct{}
+
+func (shortWriter) Write(p []byte) (int, error) { return len(p) / 2, nil }
+
+var fox = []byte(strings.Repeat("The quick brown fox jumps over the lazy dog. ", 1000))
+
+func TestChunkedWrites(t *testing.T) {
+	want := sha256.Sum256(fox)
+	for _, size := range []int{1, 7, 64, 4096, len(fox)} {
+		var buf bytes.Buffer
+		hw := NewHashingWriter(&buf, sha256.New())
+		for rest := fox; len(rest) > 0; {
+			chunk := rest[:min(size, len(rest))]
+			n, err := hw.Write(chunk)
+			if n != len(chunk) || err != nil {
+				t.Fatalf("chunks of %d: Write = %d, %v", size, n, err)
+			}
+			rest = rest[len(chunk):]
+		}
+		if !bytes.Equal(hw.Sum(), want[:]) {
+			t.Errorf("chunks of %d: sum differs from sha256.Sum256", size)
+		}
+		if !bytes.Equal(buf.Bytes(), fox) {
+			t.Errorf("chunks of %d: destination holds different bytes", size)
+		}
+	}
+}
+
+func TestCopy(t *testing.T) {
+	var buf bytes.Buffer
+	hw := NewHashingWriter(&buf, sha256.New())
+	// OneByteReader makes io.Copy write one byte at a time.
+	n, err := io.Copy(hw, iotest.OneByteReader(bytes.NewReader(fox[:500])))
+	if n != 500 || err != nil {
+		t.Fatalf("io.Copy = %d, %v", n, err)
+	}
+	want := sha256.Sum256(fox[:500])
+	if !bytes.Equal(hw.Sum(), want[:]) {
+		t.Error("sum differs from sha256.Sum256")
+	}
+}
+
+func TestEmpty(t *testing.T) {
+	hw := NewHashingWriter(io.Discard, sha256.New())
+	if n, err := hw.Write(nil); n != 0 || err != nil {
+		t.Errorf("Write(nil) = %d, %v", n, err)
+	}
+	want := sha256.Sum256(nil)
+	if !bytes.Equal(hw.Sum(), want[:]) {
+		t.Error("sum of nothing differs from sha256.Sum256(nil)")
+	}
+}
+
+// Sum does not reset the hash: writing afterwards goes on from where it was.
+func TestSumThenWrite(t *testing.T) {
+	hw := NewHashingWriter(io.Discard, sha256.New())
+	hw.Write(fox[:10])
+	hw.Sum()
+	hw.Write(fox[10:20])
+	want := sha256.Sum256(fox[:20])
+	if !bytes.Equal(hw.Sum(), want[:]) {
+		t.Error("sum after Sum and another Write is wrong")
+	}
+}
+
+func TestWriteError(t *testing.T) {
+	tests := []struct {
+		name  string
+		limit int
+		sizes []int // chunk sizes written one after the other
+		wantN []int
+	}{
+		{"fails at once", 0, []int{5}, []int{0}},
+		{"fails inside a chunk", 8, []int{5, 5}, []int{5, 3}},
+		{"fails on an exact fit", 10, []int{5, 5, 1}, []int{5, 5, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			full := &limitedWriter{limit: tt.limit}
+			hw := NewHashingWriter(full, sha256.New())
+			rest := fox
+			for i, size := range tt.sizes {
+				n, err := hw.Write(rest[:size])
+				if n != tt.wantN[i] {
+					t.Errorf("write %d: n = %d, want %d", i, n, tt.wantN[i])
+				}
+				last := i == len(tt.sizes)-1
+				if last && !errors.Is(err, errDiskFull) {
+					t.Errorf("write %d: err = %v, want %v", i, err, errDiskFull)
+				}
+				if !last && err != nil {
+					t.Errorf("write %d: err = %v, want nil", i, err)
+				}
+				rest = rest[n:]
+			}
+			stored := sha256.Sum256(full.buf.Bytes())
+			if !bytes.Equal(hw.Sum(), stored[:]) {
+				t.Error("sum does not match the bytes the destination stored")
+			}
+		})
+	}
+}
+
+func TestCopyError(t *testing.T) {
+	full := &limitedWriter{limit: 100}
+	hw := NewHashingWriter(full, sha256.New())
+	n, err := io.Copy(hw, bytes.NewReader(fox))
+	if n != 100 || !errors.Is(err, errDiskFull) {
+		t.Errorf("io.Copy = %d, %v; want 100, %v", n, err, errDiskFull)
+	}
+	stored := sha256.Sum256(fox[:100])
+	if !bytes.Equal(hw.Sum(), stored[:]) {
+		t.Error("sum does not match the 100 stored bytes")
+	}
+}
+
+func TestShortWrite(t *testing.T) {
+	hw := NewHashingWriter(shortWriter{}, sha256.New())
+	n, err := hw.Write([]byte("hello"))
+	if n != 2 || err != io.ErrShortWrite {
+		t.Errorf("Write = %d, %v; want 2, io.ErrShortWrite", n, err)
+	}
+	want := sha256.Sum256([]byte("he"))
+	if !bytes.Equal(hw.Sum(), want[:]) {
+		t.Error("sum covers bytes that were not written")
+	}
+}
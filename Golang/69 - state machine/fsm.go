@@ -0,0 +1,106 @@
+/*
+A finite state machine (FSM) is always in exactly one of a fixed set of
+states, and moves to another one only when an event arrives for which a
+transition is defined. A turnstile, for example:
+
+	locked   + coin → unlocked
+	unlocked + push → locked
+
+A push on a locked turnstile has no transition, so Fire rejects it, and the
+machine stays where it was. Writing the rules down as a table like this
+keeps them in one place instead of spread over if statements.
+
+The state and event types are type parameters, so they can be strings, ints
+or named types with a String method, which then also show up in the error
+messages.
+*/
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrInvalidTransition is returned, wrapped, by Fire when no transition is
+// defined for the event in the current state.
+var ErrInvalidTransition = errors.New("invalid transition")
+
+type transitionKey[S, E comparable] struct {
+	from  S
+	event E
+}
+
+// FSM is safe to use from several goroutines. Create it with NewFSM.
+//
+// Callbacks run while the FSM is locked, so that no other event can sneak in
+// between leaving one state and entering the next; they must not call the
+// FSM's methods.
+type FSM[S, E comparable] struct {
+	mu          sync.Mutex
+	current     S
+	transitions map[transitionKey[S, E]]S
+	onEnter     map[S]func(from S, event E)
+	onExit      map[S]func(to S, event E)
+}
+
+// NewFSM returns a machine in the initial state, with no transitions yet.
+func NewFSM[S, E comparable](initial S) *FSM[S, E] {
+	return &FSM[S, E]{
+		current:     initial,
+		transitions: make(map[transitionKey[S, E]]S),
+		onEnter:     make(map[S]func(S, E)),
+		onExit:      make(map[S]func(S, E)),
+	}
+}
+
+// AddTransition makes event move the machine from the state from to the
+// state to. Adding the same from and event again replaces the target.
+func (m *FSM[S, E]) AddTransition(from S, event E, to S) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.transitions[transitionKey[S, E]{from, event}] = to
+}
+
+// OnEnter sets the function called after the machine enters state s,
+// replacing any previous one.
+func (m *FSM[S, E]) OnEnter(s S, fn func(from S, event E)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onEnter[s] = fn
+}
+
+// OnExit sets the function called before the machine leaves state s,
+// replacing any previous one.
+func (m *FSM[S, E]) OnExit(s S, fn func(to S, event E)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onExit[s] = fn
+}
+
+func (m *FSM[S, E]) State() S {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.current
+}
+
+// Fire applies event to the current state. Without a matching transition it
+// returns an error wrapping ErrInvalidTransition and the state is unchanged.
+// A transition back to the same state still calls the callbacks.
+func (m *FSM[S, E]) Fire(event E) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	from := m.current
+	to, ok := m.transitions[transitionKey[S, E]{from, event}]
+	if !ok {
+		return fmt.Errorf("%w: event %v in state %v", ErrInvalidTransition, event, from)
+	}
+	if exit := m.onExit[from]; exit != nil {
+		exit(to, event)
+	}
+	m.current = to
+	if enter := m.onEnter[to]; enter != nil {
+		enter(from, event)
+	}
+	return nil
+}
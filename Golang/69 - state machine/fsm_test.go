@@ -0,0 +1,97 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestTurnstile(t *testing.T) {
+	tests := []struct {
+		name    string
+		events  []Event
+		want    State
+		invalid int // how many events were rejected
+	}{
+		{"starts locked", nil, Locked, 0},
+		{"coin unlocks", []Event{Coin}, Unlocked, 0},
+		{"push locks again", []Event{Coin, Push}, Locked, 0},
+		{"extra coins are kept", []Event{Coin, Coin, Coin}, Unlocked, 0},
+		{"push while locked", []Event{Push}, Locked, 1},
+		{"unknown event", []Event{"kick", Coin, "kick"}, Unlocked, 2},
+		{"two visitors", []Event{Coin, Push, Push, Coin, Push}, Locked, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTurnstile()
+			invalid := 0
+			for _, e := range tt.events {
+				if err := m.Fire(e); err != nil {
+					if !errors.Is(err, ErrInvalidTransition) {
+						t.Fatalf("Fire(%v) = %v, want ErrInvalidTransition", e, err)
+					}
+					invalid++
+				}
+			}
+			if m.State() != tt.want {
+				t.Errorf("state %v, want %v", m.State(), tt.want)
+			}
+			if invalid != tt.invalid {
+				t.Errorf("%d events rejected, want %d", invalid, tt.invalid)
+			}
+		})
+	}
+}
+
+func TestInvalidTransitionMessage(t *testing.T) {
+	err := newTurnstile().Fire(Push)
+	for _, want := range []string{"invalid transition", "push", "locked"} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("error %v does not mention %q", err, want)
+		}
+	}
+}
+
+func TestCallbacks(t *testing.T) {
+	m := newTurnstile()
+	var log []string
+	m.OnExit(Locked, func(to State, e Event) { log = append(log, "exit locked to "+to.String()) })
+	m.OnEnter(Unlocked, func(from State, e Event) { log = append(log, "enter unlocked on "+string(e)) })
+	m.OnEnter(Locked, func(from State, e Event) { log = append(log, "enter locked") })
+
+	m.Fire(Push) // rejected: no callbacks
+	m.Fire(Coin)
+	m.Fire(Coin) // unlocked to unlocked: enter runs again, no exit for unlocked
+	m.Fire(Push)
+
+	want := []string{
+		"exit locked to unlocked", "enter unlocked on coin",
+		"enter unlocked on coin",
+		"enter locked",
+	}
+	if strings.Join(log, "\n") != strings.Join(want, "\n") {
+		t.Errorf("callbacks ran as\n%s\nwant\n%s", strings.Join(log, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+// Run with -race: Fire from many goroutines.
+func TestConcurrentFire(t *testing.T) {
+	m := newTurnstile()
+	coins, passed := 0, 0
+	m.OnEnter(Unlocked, func(State, Event) { coins++ })
+	m.OnEnter(Locked, func(State, Event) { passed++ })
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m.Fire(Coin)
+			m.Fire(Push)
+		}()
+	}
+	wg.Wait()
+	if coins != 50 || passed > coins {
+		t.Errorf("%d coins and %d passed, want 50 coins and at most as many passes", coins, passed)
+	}
+}
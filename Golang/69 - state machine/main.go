@@ -0,0 +1,78 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+type State int
+
+const (
+	Locked State = iota
+	Unlocked
+)
+
+func (s State) String() string {
+	if s == Locked {
+		return "locked"
+	}
+	return "unlocked"
+}
+
+type Event string
+
+const (
+	Coin Event = "coin"
+	Push Event = "push"
+)
+
+// newTurnstile returns a locked turnstile. Inserting a coin while unlocked
+// is allowed (the coin is kept), pushing while locked is not.
+func newTurnstile() *FSM[State, Event] {
+	t := NewFSM[State, Event](Locked)
+	t.AddTransition(Locked, Coin, Unlocked)
+	t.AddTransition(Unlocked, Coin, Unlocked)
+	t.AddTransition(Unlocked, Push, Locked)
+	return t
+}
+
+func main() {
+	t := newTurnstile()
+	t.OnExit(Locked, func(to State, e Event) { fmt.Println("  exit locked on", e) })
+	t.OnEnter(Unlocked, func(from State, e Event) { fmt.Println("  enter unlocked from", from) })
+	t.OnEnter(Locked, func(from State, e Event) { fmt.Println("  enter locked from", from) })
+
+	for _, e := range []Event{Push, Coin, Coin, Push, Push, "kick"} {
+		fmt.Printf("%-5s in %v:\n", e, t.State())
+		if err := t.Fire(e); err != nil {
+			fmt.Println("  error:", err, "- invalid:", errors.Is(err, ErrInvalidTransition))
+		}
+	}
+
+	// 100 goroutines each insert a coin and push. Whatever the order, every
+	// push that was accepted needed a coin before it, and the counters are
+	// only changed inside the callbacks, which run under the FSM's lock.
+	t = newTurnstile()
+	passed, coins := 0, 0
+	t.OnEnter(Locked, func(State, Event) { passed++ })
+	t.OnEnter(Unlocked, func(State, Event) { coins++ })
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	rejected := 0
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			t.Fire(Coin)
+			if t.Fire(Push) != nil {
+				mu.Lock()
+				rejected++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	fmt.Printf("100 visitors: %d coins, %d passed, %d pushes rejected, now %v\n",
+		coins, passed, rejected, t.State())
+}
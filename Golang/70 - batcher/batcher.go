@@ -0,0 +1,149 @@
+/*
+A batcher collects items and hands them on in groups, for example to write
+rows to a database with one query per 100 rows instead of one per row. A
+batch is flushed when it is full, or when its first item has waited
+maxDelay, so that a slow trickle of items is not held back for ever.
+
+Add only appends to a buffer protected by a mutex, and pokes a background
+goroutine through a channel. That goroutine does everything else: it runs
+the time.Timer and calls flush, so flush is never called twice at the same
+time and batches arrive in the order their items were added.
+*/
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// Batcher groups items into batches of up to maxSize. Create it with
+// NewBatcher and call Close when done; Add may be called from several
+// goroutines.
+type Batcher[T any] struct {
+	maxSize  int
+	maxDelay time.Duration
+	flush    func([]T)
+
+	mu     sync.Mutex
+	buf    []T
+	closed bool
+
+	signal    chan struct{} // Add has news for the background goroutine
+	done      chan struct{} // closed by Close
+	finished  chan struct{} // closed when the goroutine has flushed and ended
+	closeOnce sync.Once
+}
+
+// NewBatcher returns a batcher calling flush with every batch. maxSize is at
+// least 1. flush owns the slice it receives.
+func NewBatcher[T any](maxSize int, maxDelay time.Duration, flush func([]T)) *Batcher[T] {
+	b := &Batcher[T]{
+		maxSize:  max(maxSize, 1),
+		maxDelay: maxDelay,
+		flush:    flush,
+		signal:   make(chan struct{}, 1),
+		done:     make(chan struct{}),
+		finished: make(chan struct{}),
+	}
+	go b.run()
+	return b
+}
+
+// Add queues item for the next batch. It panics after Close, like a send on
+// a closed channel, because the item could never be flushed.
+func (b *Batcher[T]) Add(item T) {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		panic("batcher: Add after Close")
+	}
+	b.buf = append(b.buf, item)
+	n := len(b.buf)
+	b.mu.Unlock()
+
+	// Only the first item of a batch (to start the timer) and a full batch
+	// need the goroutine. If a signal is pending already, it will see this
+	// item too, so there is no need to wait.
+	if n == 1 || n >= b.maxSize {
+		select {
+		case b.signal <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Close flushes the items still buffered and waits until flush has returned
+// for all of them. Calling it again does nothing.
+func (b *Batcher[T]) Close() {
+	b.closeOnce.Do(func() {
+		b.mu.Lock()
+		b.closed = true
+		b.mu.Unlock()
+		close(b.done)
+	})
+	<-b.finished
+}
+
+// run is the background goroutine. The timer is only running while items
+// are waiting.
+func (b *Batcher[T]) run() {
+	defer close(b.finished)
+	timer := time.NewTimer(b.maxDelay)
+	stopTimer(timer)
+	running := false
+
+	for {
+		select {
+		case <-b.signal:
+		case <-timer.C:
+			running = false
+			b.drain(true)
+		case <-b.done:
+			stopTimer(timer)
+			b.drain(true)
+			return
+		}
+		b.drain(false)
+
+		b.mu.Lock()
+		pending := len(b.buf)
+		b.mu.Unlock()
+		switch {
+		case pending > 0 && !running:
+			timer.Reset(b.maxDelay)
+			running = true
+		case pending == 0 && running:
+			stopTimer(timer)
+			running = false
+		}
+	}
+}
+
+// drain flushes the full batches in the buffer, and with all set also the
+// last, partial one.
+func (b *Batcher[T]) drain(all bool) {
+	for {
+		b.mu.Lock()
+		n := min(len(b.buf), b.maxSize)
+		if n == 0 || n < b.maxSize && !all {
+			b.mu.Unlock()
+			return
+		}
+		batch := b.buf[:n:n]
+		b.buf = b.buf[n:]
+		b.mu.Unlock()
+		// flush runs without the lock, so Add never waits for it.
+		b.flush(batch)
+	}
+}
+
+// stopTimer stops t and empties its channel, so that a later Reset does not
+// deliver an old tick.
+func stopTimer(t *time.Timer) {
+	if !t.Stop() {
+		select {
+		case <-t.C:
+		default:
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recorder collects the batches flushed by a Batcher. Every flush also
+// signals on flushed; signals that nobody waits for are merged into one.
+type recorder struct {
+	mu      sync.Mutex
+	batches [][]int
+	flushed chan struct{}
+}
+
+func newRecorder() *recorder {
+	return &recorder{flushed: make(chan struct{}, 1)}
+}
+
+func (r *recorder) flush(batch []int) {
+	r.mu.Lock()
+	r.batches = append(r.batches, batch)
+	r.mu.Unlock()
+	select {
+	case r.flushed <- struct{}{}:
+	default:
+	}
+}
+
+// wait waits until n batches have been flushed in total, failing the test
+// if that takes longer than a few seconds.
+func (r *recorder) wait(t *testing.T, n int) {
+	t.Helper()
+	timeout := time.After(5 * time.Second)
+	for len(r.get()) < n {
+		select {
+		case <-r.flushed:
+		case <-timeout:
+			t.Fatalf("%d batches flushed, want %d: %v", len(r.get()), n, r.get())
+		}
+	}
+}
+
+// quiet fails the test if another batch is flushed within d. A slow machine
+// can only make the check miss a flush, never fail a correct Batcher.
+func (r *recorder) quiet(t *testing.T, d time.Duration) {
+	t.Helper()
+	n := len(r.get())
+	timeout := time.After(d)
+	for {
+		select {
+		case <-r.flushed:
+			if len(r.get()) != n {
+				t.Errorf("unexpected flush: %v", r.get())
+				return
+			}
+		case <-timeout:
+			return
+		}
+	}
+}
+
+func (r *recorder) get() [][]int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.batches)
+}
+
+func equalBatches(a, b [][]int) bool {
+	return slices.EqualFunc(a, b, func(x, y []int) bool { return slices.Equal(x, y) })
+}
+
+func TestBatcher(t *testing.T) {
+	tests := []struct {
+		name     string
+		maxSize  int
+		maxDelay time.Duration
+		items    int
+		want     [][]int // flushed before Close
+		atClose  [][]int // flushed by Close
+	}{
+		{"size triggers", 3, time.Hour, 6,
+			[][]int{{0, 1, 2}, {3, 4, 5}}, nil},
+		{"partial waits for the timer", 3, time.Hour, 4,
+			[][]int{{0, 1, 2}}, [][]int{{3}}},
+		{"timer triggers", 100, 10 * time.Millisecond, 5,
+			[][]int{{0, 1, 2, 3, 4}}, nil},
+		{"close drains", 100, time.Hour, 3,
+			nil, [][]int{{0, 1, 2}}},
+		{"nothing added", 3, time.Hour, 0, nil, nil},
+		{"size below 1 is 1", 0, time.Hour, 2,
+			[][]int{{0}, {1}}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newRecorder()
+			b := NewBatcher(tt.maxSize, tt.maxDelay, r.flush)
+			for i := 0; i < tt.items; i++ {
+				b.Add(i)
+			}
+			r.wait(t, len(tt.want))
+			r.quiet(t, 20*time.Millisecond)
+			if got := r.get(); !equalBatches(got, tt.want) {
+				t.Errorf("before Close: flushed %v, want %v", got, tt.want)
+			}
+			// Close returns only after the last flush, no waiting needed.
+			b.Close()
+			want := append(slices.Clone(tt.want), tt.atClose...)
+			if got := r.get(); !equalBatches(got, want) {
+				t.Errorf("after Close: flushed %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestBatcherCloseTwice(t *testing.T) {
+	r := newRecorder()
+	b := NewBatcher(10, time.Hour, r.flush)
+	b.Add(1)
+	b.Close()
+	b.Close()
+	if got := r.get(); len(got) != 1 {
+		t.Errorf("flushed %v, want one batch", got)
+	}
+}
+
+func TestAddAfterClosePanics(t *testing.T) {
+	b := NewBatcher(10, time.Hour, func([]int) {})
+	b.Close()
+	defer func() {
+		if recover() == nil {
+			t.Error("Add after Close did not panic")
+		}
+	}()
+	b.Add(1)
+}
+
+// Run with -race: concurrent Adds lose and duplicate nothing, and no batch
+// is larger than maxSize.
+func TestBatcherConcurrent(t *testing.T) {
+	r := newRecorder()
+	b := NewBatcher(50, time.Millisecond, r.flush)
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				b.Add(g*500 + i)
+			}
+		}()
+	}
+	wg.Wait()
+	b.Close()
+
+	var all []int
+	for _, batch := range r.get() {
+		if len(batch) > 50 {
+			t.Errorf("batch of %d items, want at most 50", len(batch))
+		}
+		all = append(all, batch...)
+	}
+	slices.Sort(all)
+	for i, v := range all {
+		if v != i {
+			t.Fatalf("items lost or duplicated: position %d holds %d", i, v)
+		}
+	}
+	if len(all) != 4000 {
+		t.Errorf("%d items flushed, want 4000", len(all))
+	}
+}
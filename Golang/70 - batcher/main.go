@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+func main() {
+	// Size: 10 items with maxSize 4 give two full batches right away; the
+	// last two wait for the timer.
+	start := time.Now()
+	b := NewBatcher(4, 50*time.Millisecond, func(batch []int) {
+		fmt.Printf("  %3dms flush %v\n", time.Since(start).Milliseconds(), batch)
+	})
+	fmt.Println("10 items at once:")
+	for i := 1; i <= 10; i++ {
+		b.Add(i)
+	}
+	time.Sleep(80 * time.Millisecond)
+
+	// Time: a slow trickle is flushed every 50ms, well before 4 items.
+	fmt.Println("one item every 20ms:")
+	start = time.Now()
+	for i := 11; i <= 15; i++ {
+		b.Add(i)
+		time.Sleep(20 * time.Millisecond)
+	}
+	// Close: the last partial batch is flushed at once, without waiting
+	// for the timer of item 14.
+	b.Add(16)
+	fmt.Printf("  %3dms close\n", time.Since(start).Milliseconds())
+	b.Close()
+
+	// Many goroutines adding: every item arrives exactly once, in batches
+	// of at most 100.
+	var (
+		mu      sync.Mutex
+		seen    = map[int]int{}
+		batches int
+		largest int
+	)
+	b = NewBatcher(100, 5*time.Millisecond, func(batch []int) {
+		mu.Lock()
+		defer mu.Unlock()
+		batches++
+		largest = max(largest, len(batch))
+		for _, v := range batch {
+			seen[v]++
+		}
+	})
+	var wg sync.WaitGroup
+	for g := 0; g < 10; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				b.Add(g*1000 + i)
+			}
+		}()
+	}
+	wg.Wait()
+	b.Close()
+	lost, duplicated := 0, 0
+	for i := 0; i < 10000; i++ {
+		switch seen[i] {
+		case 0:
+			lost++
+		case 1:
+		default:
+			duplicated++
+		}
+	}
+	fmt.Printf("10000 items in %d batches (largest %d): %d lost, %d duplicated\n",
+		batches, largest, lost, duplicated)
+}
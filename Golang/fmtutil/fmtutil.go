@@ -0,0 +1,72 @@
+/*
+Package fmtutil collects the small formatting helpers used by the examples,
+so every main() does not have to repeat the same fmt calls.
+
+Import it from any example inside the Golang folder with:
+
+	import "Golang/fmtutil"
+*/
+package fmtutil
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"unicode/utf8"
+)
+
+// FormatLabeled returns v printed with its default format after a label,
+// for example FormatLabeled("Age", 30) returns "Age: 30".
+func FormatLabeled(label string, v any) string {
+	return fmt.Sprintf("%s: %v", label, v)
+}
+
+// FormatTable returns rows as an aligned table, one row per line, with the
+// columns separated by two spaces. The first row is treated as the header and
+// is underlined with dashes.
+//
+// Rows shorter than the longest one are padded with blank cells. Widths are
+// counted in runes, not bytes, so "é" or "日" take one column like "e" does.
+// An empty rows slice returns an empty string.
+func FormatTable(rows [][]string) string {
+	columns := 0
+	for _, row := range rows {
+		columns = max(columns, len(row))
+	}
+	if columns == 0 {
+		return ""
+	}
+
+	// The dash line under the header must be as wide as each column.
+	widths := make([]int, columns)
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+		}
+	}
+	underline := make([]string, columns)
+	for i, w := range widths {
+		underline[i] = strings.Repeat("-", w)
+	}
+
+	var sb strings.Builder
+	tw := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	writeRow := func(row []string) {
+		cells := make([]string, columns)
+		copy(cells, row)
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	writeRow(rows[0])
+	writeRow(underline)
+	for _, row := range rows[1:] {
+		writeRow(row)
+	}
+	tw.Flush()
+
+	// Blank cells at the end of a row leave padding behind, trim it.
+	lines := strings.Split(strings.TrimSuffix(sb.String(), "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
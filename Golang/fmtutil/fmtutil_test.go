@@ -0,0 +1,58 @@
+package fmtutil
+
+import "testing"
+
+func TestFormatLabeled(t *testing.T) {
+	tests := []struct {
+		label string
+		v     any
+		want  string
+	}{
+		{"Age", 30, "Age: 30"},
+		{"Name", "Ada", "Name: Ada"},
+		{"Pi", 3.14, "Pi: 3.14"},
+		{"Ok", true, "Ok: true"},
+		{"", nil, ": <nil>"},
+	}
+	for _, tt := range tests {
+		if got := FormatLabeled(tt.label, tt.v); got != tt.want {
+			t.Errorf("FormatLabeled(%q, %v) = %q, want %q", tt.label, tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestFormatTable(t *testing.T) {
+	tests := []struct {
+		name string
+		rows [][]string
+		want string
+	}{
+		{"nil", nil, ""},
+		{"empty rows", [][]string{{}, {}}, ""},
+		{"header only", [][]string{{"Name", "Age"}},
+			"Name  Age\n" +
+				"----  ---\n"},
+		{"aligned", [][]string{{"Name", "Age"}, {"Ada", "36"}, {"Grace", "85"}},
+			"Name   Age\n" +
+				"-----  ---\n" +
+				"Ada    36\n" +
+				"Grace  85\n"},
+		{"unequal rows are padded", [][]string{{"A", "B", "C"}, {"x"}, {"xx", "yy", "zz"}},
+			"A   B   C\n" +
+				"--  --  --\n" +
+				"x\n" +
+				"xx  yy  zz\n"},
+		{"runes, not bytes", [][]string{{"Word", "Lang"}, {"café", "fr"}, {"日本", "ja"}},
+			"Word  Lang\n" +
+				"----  ----\n" +
+				"café  fr\n" +
+				"日本    ja\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatTable(tt.rows); got != tt.want {
+				t.Errorf("got\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
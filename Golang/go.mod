@@ -0,0 +1,3 @@
+module Golang
+
+go 1.22